@@ -0,0 +1,174 @@
+// Package agent implements a tool-calling agent loop: the model is invoked
+// against the running history, and for as long as it keeps asking for tool
+// calls those tools are executed and their results appended to that same
+// history, until it produces a terminal assistant message.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/luomsis/agent4everything/translator/eino/agent/toolbox"
+)
+
+// AgentAction is a single step of the agent loop, emitted over the events
+// channel passed to Run so callers can relay step-by-step progress (e.g. as
+// SSE events) while the loop is still executing.
+type AgentAction struct {
+	Type    string `json:"type"`              // "tool_call", "tool_result", "pending", or "message"
+	Tool    string `json:"tool,omitempty"`    // set for tool_call / tool_result
+	Args    string `json:"args,omitempty"`    // raw JSON arguments, set for tool_call
+	Result  string `json:"result,omitempty"`  // set for tool_result
+	Content string `json:"content,omitempty"` // set for the final "message" action
+	ID      string `json:"id,omitempty"`      // confirmation id, set for the "pending" action
+}
+
+// ToolCallingChatAssistant runs the model/tool loop described above. Mode
+// controls whether tool calls are executed automatically or returned to the
+// caller for confirmation before running.
+type ToolCallingChatAssistant struct {
+	model   model.ToolCallingChatModel
+	tools   *toolbox.Registry
+	autoRun bool
+}
+
+// New builds a ToolCallingChatAssistant. When autoRun is false, Run stops
+// after the first round of tool calls and returns them as "tool_call"
+// actions instead of executing the tools, so the caller can confirm before
+// RunWithResults continues the loop.
+func New(ctx context.Context, chatModel model.ToolCallingChatModel, tools *toolbox.Registry, autoRun bool) (*ToolCallingChatAssistant, error) {
+	bound, err := chatModel.WithTools(toToolInfos(tools))
+	if err != nil {
+		return nil, fmt.Errorf("agent: failed to bind tools to model: %v", err)
+	}
+	return &ToolCallingChatAssistant{model: bound, tools: tools, autoRun: autoRun}, nil
+}
+
+// Run executes the agent loop against history, emitting an AgentAction for
+// every tool call, tool result and the final message onto events (if
+// non-nil) as they happen.
+//
+// When the assistant was built with autoRun=false, Run only performs a
+// single model call: if that call requests tool calls, it emits them as
+// "tool_call" actions and returns that message (with ToolCalls populated)
+// instead of executing anything, so the caller can confirm before invoking
+// the full loop via RunWithResults.
+func (a *ToolCallingChatAssistant) Run(ctx context.Context, history []*schema.Message, events chan<- AgentAction) (*schema.Message, error) {
+	if !a.autoRun {
+		msg, err := a.model.Generate(ctx, history)
+		if err != nil {
+			return nil, fmt.Errorf("agent: model call failed: %v", err)
+		}
+		if len(msg.ToolCalls) > 0 {
+			if events != nil {
+				for _, call := range msg.ToolCalls {
+					events <- AgentAction{Type: "tool_call", Tool: call.Function.Name, Args: call.Function.Arguments}
+				}
+			}
+			return msg, nil
+		}
+		if events != nil {
+			events <- AgentAction{Type: "message", Content: msg.Content}
+		}
+		return msg, nil
+	}
+
+	return a.loop(ctx, history, events)
+}
+
+// RunWithResults continues a paused (autoRun=false) loop: it appends the
+// caller-confirmed tool results for pending onto history as schema.Tool
+// messages and resumes the full auto-executing loop from there.
+func (a *ToolCallingChatAssistant) RunWithResults(ctx context.Context, history []*schema.Message, pending *schema.Message, results map[string]string, events chan<- AgentAction) (*schema.Message, error) {
+	history = append(append([]*schema.Message{}, history...), pending)
+	history = append(history, resultMessages(pending.ToolCalls, results, events)...)
+	return a.loop(ctx, history, events)
+}
+
+// loop drives the auto-executing model/tool cycle: each round appends the
+// model's message and its tool results onto history before calling the
+// model again, so every round sees the complete conversation (system
+// prompt, original question, and every prior tool call/result) rather than
+// just the latest tool round.
+func (a *ToolCallingChatAssistant) loop(ctx context.Context, history []*schema.Message, events chan<- AgentAction) (*schema.Message, error) {
+	for {
+		msg, err := a.model.Generate(ctx, history)
+		if err != nil {
+			return nil, fmt.Errorf("agent: loop failed: %v", err)
+		}
+		if len(msg.ToolCalls) == 0 {
+			if events != nil {
+				events <- AgentAction{Type: "message", Content: msg.Content}
+			}
+			return msg, nil
+		}
+
+		history = append(append([]*schema.Message{}, history...), msg)
+		history = append(history, a.executeToolCalls(ctx, msg.ToolCalls, events)...)
+	}
+}
+
+// executeToolCalls runs every requested tool call against the registry and
+// returns the results as schema.Tool messages, so the next model
+// invocation sees them in history.
+func (a *ToolCallingChatAssistant) executeToolCalls(ctx context.Context, calls []schema.ToolCall, events chan<- AgentAction) []*schema.Message {
+	out := make([]*schema.Message, 0, len(calls))
+	for _, call := range calls {
+		if events != nil {
+			events <- AgentAction{Type: "tool_call", Tool: call.Function.Name, Args: call.Function.Arguments}
+		}
+
+		spec, ok := a.tools.Get(call.Function.Name)
+		result := ""
+		if !ok {
+			result = fmt.Sprintf("error: unknown tool %q", call.Function.Name)
+		} else {
+			out2, err := spec.Impl(ctx, json.RawMessage(call.Function.Arguments))
+			if err != nil {
+				result = fmt.Sprintf("error: %v", err)
+			} else {
+				result = out2
+			}
+		}
+
+		if events != nil {
+			events <- AgentAction{Type: "tool_result", Tool: call.Function.Name, Result: result}
+		}
+		out = append(out, &schema.Message{Role: schema.Tool, Content: result, ToolCallID: call.ID})
+	}
+	return out
+}
+
+// resultMessages converts the caller-confirmed tool results RunWithResults
+// receives into schema.Tool messages, emitting a "tool_result" action for
+// each the same way executeToolCalls does for the auto-run path.
+func resultMessages(calls []schema.ToolCall, results map[string]string, events chan<- AgentAction) []*schema.Message {
+	out := make([]*schema.Message, 0, len(calls))
+	for _, call := range calls {
+		result := results[call.ID]
+		if events != nil {
+			events <- AgentAction{Type: "tool_result", Tool: call.Function.Name, Result: result}
+		}
+		out = append(out, &schema.Message{Role: schema.Tool, Content: result, ToolCallID: call.ID})
+	}
+	return out
+}
+
+// toToolInfos converts a toolbox.Registry into the schema.ToolInfo slice
+// Eino's ToolCallingChatModel.WithTools expects.
+func toToolInfos(tools *toolbox.Registry) []*schema.ToolInfo {
+	specs := tools.List()
+	out := make([]*schema.ToolInfo, 0, len(specs))
+	for _, t := range specs {
+		out = append(out, &schema.ToolInfo{
+			Name:        t.Name,
+			Desc:        t.Description,
+			ParamsOneOf: schema.NewParamsOneOfByJSONSchema(t.Parameters),
+		})
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/luomsis/agent4everything/translator/eino/agent/toolbox"
+)
+
+// fakeToolCallingModel is a model.ToolCallingChatModel test double whose
+// Generate responses are scripted by gen, and which records every history
+// it was called with so tests can assert the loop carries the full
+// conversation forward across rounds rather than just the latest output.
+type fakeToolCallingModel struct {
+	gen       func(call int, history []*schema.Message) (*schema.Message, error)
+	call      int
+	histories [][]*schema.Message
+}
+
+func (f *fakeToolCallingModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	f.histories = append(f.histories, append([]*schema.Message{}, input...))
+	f.call++
+	return f.gen(f.call, input)
+}
+
+func (f *fakeToolCallingModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return nil, nil
+}
+
+func (f *fakeToolCallingModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return f, nil
+}
+
+func echoTool() toolbox.ToolSpec {
+	return toolbox.ToolSpec{
+		Name: "echo",
+		Impl: func(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+			return "echoed", nil
+		},
+	}
+}
+
+// TestLoopCarriesFullHistoryAcrossRounds is a regression test for the bug
+// where each round only saw the tool results from the round just run,
+// losing the system prompt and original question on every round after the
+// first. The second Generate call must see everything: the seed history,
+// the first assistant message, and its tool result.
+func TestLoopCarriesFullHistoryAcrossRounds(t *testing.T) {
+	seed := []*schema.Message{
+		schema.SystemMessage("you are a test assistant"),
+		{Role: schema.User, Content: "what does echo say?"},
+	}
+
+	fake := &fakeToolCallingModel{}
+	fake.gen = func(call int, history []*schema.Message) (*schema.Message, error) {
+		switch call {
+		case 1:
+			return &schema.Message{
+				Role: schema.Assistant,
+				ToolCalls: []schema.ToolCall{
+					{ID: "call-1", Function: schema.FunctionCall{Name: "echo", Arguments: "{}"}},
+				},
+			}, nil
+		default:
+			return &schema.Message{Role: schema.Assistant, Content: "done"}, nil
+		}
+	}
+
+	a := &ToolCallingChatAssistant{model: fake, tools: toolbox.NewRegistry(echoTool()), autoRun: true}
+
+	final, err := a.Run(context.Background(), seed, nil)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if final.Content != "done" {
+		t.Fatalf("final message = %q, want %q", final.Content, "done")
+	}
+
+	if len(fake.histories) != 2 {
+		t.Fatalf("model was called %d times, want 2", len(fake.histories))
+	}
+
+	secondCall := fake.histories[1]
+	if len(secondCall) != 4 {
+		t.Fatalf("second Generate call saw %d messages, want 4 (system, user, assistant tool-call, tool result): %+v", len(secondCall), secondCall)
+	}
+	if secondCall[0].Role != schema.System || secondCall[0].Content != seed[0].Content {
+		t.Errorf("second call lost the original system prompt: %+v", secondCall[0])
+	}
+	if secondCall[1].Role != schema.User || secondCall[1].Content != seed[1].Content {
+		t.Errorf("second call lost the original user question: %+v", secondCall[1])
+	}
+	if secondCall[2].Role != schema.Assistant || len(secondCall[2].ToolCalls) != 1 {
+		t.Errorf("second call lost the first round's assistant tool-call message: %+v", secondCall[2])
+	}
+	if secondCall[3].Role != schema.Tool || secondCall[3].Content != "echoed" {
+		t.Errorf("second call lost the first round's tool result: %+v", secondCall[3])
+	}
+}
+
+// TestRunAutoRunFalseReturnsToolCallsWithoutExecuting verifies the
+// confirmation path: with autoRun=false, Run stops after one model call and
+// returns pending tool calls instead of running them.
+func TestRunAutoRunFalseReturnsToolCallsWithoutExecuting(t *testing.T) {
+	fake := &fakeToolCallingModel{
+		gen: func(call int, history []*schema.Message) (*schema.Message, error) {
+			return &schema.Message{
+				Role: schema.Assistant,
+				ToolCalls: []schema.ToolCall{
+					{ID: "call-1", Function: schema.FunctionCall{Name: "echo", Arguments: "{}"}},
+				},
+			}, nil
+		},
+	}
+
+	a := &ToolCallingChatAssistant{model: fake, tools: toolbox.NewRegistry(echoTool()), autoRun: false}
+
+	msg, err := a.Run(context.Background(), []*schema.Message{{Role: schema.User, Content: "hi"}}, nil)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(msg.ToolCalls) != 1 {
+		t.Fatalf("Run returned %d tool calls, want 1 (the tool should not have been executed)", len(msg.ToolCalls))
+	}
+	if len(fake.histories) != 1 {
+		t.Fatalf("model was called %d times, want 1", len(fake.histories))
+	}
+}
+
+// TestRunWithResultsAppendsConfirmedResultsAndResumes verifies
+// RunWithResults appends the caller-confirmed tool results onto history
+// before resuming the loop, so the resumed round also sees the full prior
+// conversation.
+func TestRunWithResultsAppendsConfirmedResultsAndResumes(t *testing.T) {
+	pending := &schema.Message{
+		Role: schema.Assistant,
+		ToolCalls: []schema.ToolCall{
+			{ID: "call-1", Function: schema.FunctionCall{Name: "echo", Arguments: "{}"}},
+		},
+	}
+	seed := []*schema.Message{{Role: schema.User, Content: "hi"}}
+
+	fake := &fakeToolCallingModel{
+		gen: func(call int, history []*schema.Message) (*schema.Message, error) {
+			return &schema.Message{Role: schema.Assistant, Content: "ok"}, nil
+		},
+	}
+	a := &ToolCallingChatAssistant{model: fake, tools: toolbox.NewRegistry(echoTool()), autoRun: true}
+
+	final, err := a.RunWithResults(context.Background(), seed, pending, map[string]string{"call-1": "confirmed-result"}, nil)
+	if err != nil {
+		t.Fatalf("RunWithResults returned error: %v", err)
+	}
+	if final.Content != "ok" {
+		t.Fatalf("final message = %q, want %q", final.Content, "ok")
+	}
+
+	resumedHistory := fake.histories[0]
+	if len(resumedHistory) != 3 {
+		t.Fatalf("resumed call saw %d messages, want 3 (user, pending, tool result): %+v", len(resumedHistory), resumedHistory)
+	}
+	if resumedHistory[2].Role != schema.Tool || resumedHistory[2].Content != "confirmed-result" {
+		t.Errorf("resumed call didn't see the confirmed tool result: %+v", resumedHistory[2])
+	}
+}
@@ -0,0 +1,109 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+)
+
+// calculatorTool evaluates a basic arithmetic expression, so the model
+// doesn't have to do mental math itself (and get it wrong).
+func calculatorTool() ToolSpec {
+	return ToolSpec{
+		Name:        "calculator",
+		Description: "Evaluate an arithmetic expression, e.g. \"(3 + 4) * 2 / 7\".",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"expression": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"expression"},
+		},
+		Impl: runCalculator,
+	}
+}
+
+func runCalculator(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+	var args struct {
+		Expression string `json:"expression"`
+	}
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return "", fmt.Errorf("calculator: invalid arguments: %v", err)
+	}
+
+	expr, err := parser.ParseExpr(args.Expression)
+	if err != nil {
+		return "", fmt.Errorf("calculator: invalid expression: %v", err)
+	}
+
+	result, err := evalArith(expr)
+	if err != nil {
+		return "", fmt.Errorf("calculator: %v", err)
+	}
+	return fmt.Sprintf("%v", result), nil
+}
+
+// evalArith walks a parsed Go expression tree restricted to +, -, *, /,
+// parentheses and numeric literals. Reusing go/parser keeps this tool free
+// of a third-party expression-evaluation dependency.
+func evalArith(expr ast.Expr) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return 0, fmt.Errorf("unsupported literal %q", e.Value)
+		}
+		var f float64
+		if _, err := fmt.Sscanf(e.Value, "%g", &f); err != nil {
+			return 0, fmt.Errorf("invalid number %q", e.Value)
+		}
+		return f, nil
+
+	case *ast.ParenExpr:
+		return evalArith(e.X)
+
+	case *ast.UnaryExpr:
+		x, err := evalArith(e.X)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.SUB:
+			return -x, nil
+		case token.ADD:
+			return x, nil
+		default:
+			return 0, fmt.Errorf("unsupported unary operator %q", e.Op)
+		}
+
+	case *ast.BinaryExpr:
+		x, err := evalArith(e.X)
+		if err != nil {
+			return 0, err
+		}
+		y, err := evalArith(e.Y)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		default:
+			return 0, fmt.Errorf("unsupported operator %q", e.Op)
+		}
+
+	default:
+		return 0, fmt.Errorf("unsupported expression")
+	}
+}
@@ -0,0 +1,79 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dirTreeTool lists the file tree under a given path, up to max_depth
+// levels (default 3),
+// so the model can orient itself in a repository without a shell. Like
+// shell_exec, it's confined to shellExecWorkDir: absolute paths and ".."
+// segments are rejected rather than passed through to filepath.WalkDir.
+func dirTreeTool() ToolSpec {
+	return ToolSpec{
+		Name:        "dir_tree",
+		Description: "List files and directories under a path (relative to the server's working directory), up to a max depth.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"path":      map[string]interface{}{"type": "string", "description": "Root path to list, defaults to \".\""},
+				"max_depth": map[string]interface{}{"type": "integer", "description": "Maximum depth to descend, defaults to 3"},
+			},
+		},
+		Impl: runDirTree,
+	}
+}
+
+func runDirTree(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+	var args struct {
+		Path     string `json:"path"`
+		MaxDepth int    `json:"max_depth"`
+	}
+	if len(rawArgs) > 0 {
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return "", fmt.Errorf("dir_tree: invalid arguments: %v", err)
+		}
+	}
+	if args.Path == "" {
+		args.Path = "."
+	}
+	if filepath.IsAbs(args.Path) {
+		return "", fmt.Errorf("dir_tree: absolute paths are not allowed (%q)", args.Path)
+	}
+	if pathEscapesWorkDir(args.Path) {
+		return "", fmt.Errorf("dir_tree: paths may not contain \"..\" (%q)", args.Path)
+	}
+	if args.MaxDepth <= 0 {
+		args.MaxDepth = 3
+	}
+
+	root := filepath.Join(shellExecWorkDir, args.Path)
+	var b strings.Builder
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		depth := 0
+		if rel != "." {
+			depth = strings.Count(rel, string(filepath.Separator)) + 1
+		}
+		if depth > args.MaxDepth {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		fmt.Fprintf(&b, "%s%s\n", strings.Repeat("  ", depth), d.Name())
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("dir_tree: failed to walk %q: %v", root, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,109 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const httpFetchMaxBody = 64 * 1024 // avoid blowing up the model's context on large pages
+
+// httpFetchTool retrieves a URL over HTTP(S) GET and returns its body,
+// truncated to a safe size. The host (and any redirect target) is checked
+// against private/loopback/link-local ranges to prevent SSRF.
+func httpFetchTool() ToolSpec {
+	return ToolSpec{
+		Name:        "http_fetch",
+		Description: "Fetch a public URL via HTTP GET and return its response body (truncated). Cannot reach private/internal addresses.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"url": map[string]interface{}{"type": "string", "description": "URL to fetch"},
+			},
+			"required": []string{"url"},
+		},
+		Impl: runHTTPFetch,
+	}
+}
+
+func runHTTPFetch(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+	var args struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return "", fmt.Errorf("http_fetch: invalid arguments: %v", err)
+	}
+	if args.URL == "" {
+		return "", fmt.Errorf("http_fetch: url is required")
+	}
+
+	if _, err := validateHTTPFetchURL(args.URL); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, args.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("http_fetch: failed to build request: %v", err)
+	}
+
+	client := &http.Client{
+		Timeout: 15 * time.Second,
+		// Revalidate every hop: without this, a first request to an
+		// allowed host could 30x to a disallowed one (e.g. the metadata
+		// service) and the Go client would follow it unchecked.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			_, err := validateHTTPFetchURL(req.URL.String())
+			return err
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("http_fetch: request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpFetchMaxBody))
+	if err != nil {
+		return "", fmt.Errorf("http_fetch: failed to read response: %v", err)
+	}
+
+	return fmt.Sprintf("HTTP %d\n%s", resp.StatusCode, body), nil
+}
+
+// validateHTTPFetchURL rejects everything but plain http(s) URLs whose host
+// resolves only to public addresses, so the model can't use this tool for
+// SSRF against loopback, link-local, or other private-network services
+// (e.g. a cloud metadata endpoint).
+func validateHTTPFetchURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("http_fetch: invalid url: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("http_fetch: scheme must be http or https, got %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return nil, fmt.Errorf("http_fetch: url has no host")
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return nil, fmt.Errorf("http_fetch: failed to resolve host %q: %v", host, err)
+	}
+	for _, ip := range ips {
+		if isDisallowedFetchIP(ip) {
+			return nil, fmt.Errorf("http_fetch: host %q resolves to a disallowed address (%s)", host, ip)
+		}
+	}
+	return u, nil
+}
+
+func isDisallowedFetchIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate()
+}
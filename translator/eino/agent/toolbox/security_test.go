@@ -0,0 +1,102 @@
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestIsDisallowedFetchIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"169.254.169.254", true}, // cloud metadata service
+		{"10.0.0.5", true},
+		{"192.168.1.1", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"8.8.8.8", false},
+		{"93.184.216.34", false},
+	}
+	for _, tt := range tests {
+		got := isDisallowedFetchIP(net.ParseIP(tt.ip))
+		if got != tt.want {
+			t.Errorf("isDisallowedFetchIP(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestValidateHTTPFetchURLRejectsBadScheme(t *testing.T) {
+	for _, u := range []string{"file:///etc/passwd", "ftp://example.com", "gopher://example.com"} {
+		if _, err := validateHTTPFetchURL(u); err == nil {
+			t.Errorf("validateHTTPFetchURL(%q) = nil error, want rejection", u)
+		}
+	}
+}
+
+func TestValidateHTTPFetchURLRejectsLoopbackHost(t *testing.T) {
+	if _, err := validateHTTPFetchURL("http://localhost/"); err == nil {
+		t.Error("validateHTTPFetchURL(\"http://localhost/\") = nil error, want rejection")
+	}
+	if _, err := validateHTTPFetchURL("http://127.0.0.1/"); err == nil {
+		t.Error("validateHTTPFetchURL(\"http://127.0.0.1/\") = nil error, want rejection")
+	}
+}
+
+func TestPathEscapesWorkDir(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"a/b/c", false},
+		{".", false},
+		{"../etc/passwd", true},
+		{"a/../../etc/passwd", true},
+		{"a/..b/c", false}, // "..b" is not a ".." segment
+	}
+	for _, tt := range tests {
+		if got := pathEscapesWorkDir(tt.path); got != tt.want {
+			t.Errorf("pathEscapesWorkDir(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRunDirTreeRejectsAbsolutePath(t *testing.T) {
+	args, _ := json.Marshal(map[string]string{"path": "/etc"})
+	if _, err := runDirTree(context.Background(), args); err == nil {
+		t.Error("runDirTree with an absolute path = nil error, want rejection")
+	}
+}
+
+func TestRunDirTreeRejectsPathEscape(t *testing.T) {
+	args, _ := json.Marshal(map[string]string{"path": "../.."})
+	_, err := runDirTree(context.Background(), args)
+	if err == nil {
+		t.Fatal("runDirTree with a \"..\" path = nil error, want rejection")
+	}
+	if !strings.Contains(err.Error(), "\"..\"") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateShellExecArgsRejectsPathEscape(t *testing.T) {
+	if err := validateShellExecArgs("cat", []string{"../secret"}); err == nil {
+		t.Error("validateShellExecArgs with a \"..\" arg = nil error, want rejection")
+	}
+	if err := validateShellExecArgs("cat", []string{"/etc/passwd"}); err == nil {
+		t.Error("validateShellExecArgs with an absolute arg = nil error, want rejection")
+	}
+}
+
+func TestValidateShellExecArgsRestrictsGitSubcommands(t *testing.T) {
+	if err := validateShellExecArgs("git", []string{"push"}); err == nil {
+		t.Error("validateShellExecArgs(\"git\", [\"push\"]) = nil error, want rejection")
+	}
+	if err := validateShellExecArgs("git", []string{"log"}); err != nil {
+		t.Errorf("validateShellExecArgs(\"git\", [\"log\"]) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,146 @@
+package toolbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// shellExecAllowlist bounds what shell_exec can run. Commands are executed
+// directly (not through a shell), so there is no risk of the model smuggling
+// in "; rm -rf /" via argument content — only the binaries listed here can
+// ever be invoked, and only for shellExecTimeout before being killed.
+// Binary allowlisting alone isn't containment, though: runShellExec also
+// confines every command to shellExecWorkDir and rejects arguments that
+// would let it read or touch anything outside it.
+var shellExecAllowlist = map[string]bool{
+	"ls":   true,
+	"cat":  true,
+	"grep": true,
+	"echo": true,
+	"pwd":  true,
+	"head": true,
+	"tail": true,
+	"wc":   true,
+	"git":  true,
+}
+
+// shellExecWorkDir is the fixed directory every shell_exec command runs in.
+// Arguments are validated against it below rather than passed through
+// untouched, so the model can inspect this directory's contents but can't
+// walk out of it via an absolute path or "..".
+const shellExecWorkDir = "."
+
+const shellExecTimeout = 10 * time.Second
+
+// gitSubcommands allowlists the git subcommands shell_exec may run.
+// Anything that can write to the working tree, touch history, or talk to a
+// remote (clone, fetch, pull, push, remote, submodule, config, ...) is
+// excluded — some of those accept remote-helper syntax (e.g.
+// "ext::sh -c ...") that would otherwise turn "git" into arbitrary code
+// execution.
+var gitSubcommands = map[string]bool{
+	"status":   true,
+	"log":      true,
+	"diff":     true,
+	"show":     true,
+	"branch":   true,
+	"ls-files": true,
+	"grep":     true,
+}
+
+// shellExecTool runs a single allowlisted command with arguments, with no
+// shell interpretation and a hard timeout, so the model can inspect the
+// working tree without gaining arbitrary code execution.
+func shellExecTool() ToolSpec {
+	return ToolSpec{
+		Name:        "shell_exec",
+		Description: "Run an allowlisted shell command (ls, cat, grep, echo, pwd, head, tail, wc, git) with arguments, confined to the server's working directory. No shell metacharacters are interpreted.",
+		Parameters: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"command": map[string]interface{}{"type": "string", "description": "Binary to run, must be in the allowlist"},
+				"args":    map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+			},
+			"required": []string{"command"},
+		},
+		Impl: runShellExec,
+	}
+}
+
+func runShellExec(ctx context.Context, rawArgs json.RawMessage) (string, error) {
+	var args struct {
+		Command string   `json:"command"`
+		Args    []string `json:"args"`
+	}
+	if err := json.Unmarshal(rawArgs, &args); err != nil {
+		return "", fmt.Errorf("shell_exec: invalid arguments: %v", err)
+	}
+	if !shellExecAllowlist[args.Command] {
+		return "", fmt.Errorf("shell_exec: command %q is not allowlisted", args.Command)
+	}
+	if err := validateShellExecArgs(args.Command, args.Args); err != nil {
+		return "", err
+	}
+
+	execCtx, cancel := context.WithTimeout(ctx, shellExecTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(execCtx, args.Command, args.Args...)
+	cmd.Dir = shellExecWorkDir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("shell_exec: %s failed: %v (stderr: %s)", args.Command, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// validateShellExecArgs rejects arguments that would let command escape
+// shellExecWorkDir (absolute paths, ".." path segments) and, for git,
+// restricts it to gitSubcommands.
+func validateShellExecArgs(command string, args []string) error {
+	for _, a := range args {
+		if filepath.IsAbs(a) {
+			return fmt.Errorf("shell_exec: absolute paths are not allowed (%q)", a)
+		}
+		if pathEscapesWorkDir(a) {
+			return fmt.Errorf("shell_exec: paths may not contain \"..\" (%q)", a)
+		}
+	}
+
+	if command == "git" {
+		if len(args) == 0 || !gitSubcommands[args[0]] {
+			return fmt.Errorf("shell_exec: git subcommand must be one of %s", strings.Join(sortedGitSubcommands(), ", "))
+		}
+	}
+	return nil
+}
+
+// pathEscapesWorkDir reports whether arg contains a ".." path segment,
+// which filepath.IsAbs alone wouldn't catch (e.g. "../../../etc/passwd").
+func pathEscapesWorkDir(arg string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(arg), "/") {
+		if part == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedGitSubcommands() []string {
+	out := make([]string, 0, len(gitSubcommands))
+	for name := range gitSubcommands {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,86 @@
+// Package toolbox holds the built-in tools available to
+// agent.ToolCallingChatAssistant's agent loop.
+package toolbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ToolSpec describes a single callable tool: its name and JSON-schema
+// parameters as seen by the model, and the Go function that actually
+// executes it once the model asks to call it.
+type ToolSpec struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{} // JSON schema, passed to the model as-is
+	Impl        func(ctx context.Context, rawArgs json.RawMessage) (string, error)
+}
+
+// Registry is an ordered, named collection of tools available to an agent
+// loop. Zero value is not usable; use NewRegistry or Default.
+type Registry struct {
+	tools []ToolSpec
+	byName map[string]ToolSpec
+}
+
+// NewRegistry builds a Registry from the given tools.
+func NewRegistry(tools ...ToolSpec) *Registry {
+	r := &Registry{byName: make(map[string]ToolSpec, len(tools))}
+	for _, t := range tools {
+		r.Register(t)
+	}
+	return r
+}
+
+// Register adds or replaces a tool in the registry.
+func (r *Registry) Register(t ToolSpec) {
+	if _, exists := r.byName[t.Name]; !exists {
+		r.tools = append(r.tools, t)
+	} else {
+		for i := range r.tools {
+			if r.tools[i].Name == t.Name {
+				r.tools[i] = t
+			}
+		}
+	}
+	r.byName[t.Name] = t
+}
+
+// List returns all registered tools in registration order.
+func (r *Registry) List() []ToolSpec {
+	return r.tools
+}
+
+// Get looks up a tool by name.
+func (r *Registry) Get(name string) (ToolSpec, bool) {
+	t, ok := r.byName[name]
+	return t, ok
+}
+
+// Subset returns a new Registry containing only the named tools, in the
+// order requested, so callers (e.g. a per-request "tools" field) can limit
+// which tools the model is offered.
+func (r *Registry) Subset(names []string) (*Registry, error) {
+	sub := &Registry{byName: make(map[string]ToolSpec, len(names))}
+	for _, name := range names {
+		t, ok := r.byName[name]
+		if !ok {
+			return nil, fmt.Errorf("toolbox: unknown tool %q", name)
+		}
+		sub.Register(t)
+	}
+	return sub, nil
+}
+
+// Default returns the built-in toolbox: dir_tree, http_fetch, shell_exec and
+// calculator.
+func Default() *Registry {
+	return NewRegistry(
+		dirTreeTool(),
+		httpFetchTool(),
+		shellExecTool(),
+		calculatorTool(),
+	)
+}
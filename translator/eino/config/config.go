@@ -0,0 +1,67 @@
+// Package config loads the YAML-driven model/prompt configuration that
+// replaces the service's previously hardcoded system prompt and model
+// selection: a top-level config.yaml plus one YAML file per model under a
+// models/ directory.
+package config
+
+import (
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/luomsis/agent4everything/translator/eino/providers"
+)
+
+// Global is the top-level config.yaml: which model serves by default and
+// where to find the per-model YAML files.
+type Global struct {
+	DefaultModel string `yaml:"default_model"`
+	ModelsDir    string `yaml:"models_dir"`
+}
+
+// Parameters are the sampling/decoding knobs applied to every request
+// against a model, mirroring the OpenAI-style fields most providers share.
+type Parameters struct {
+	Temperature *float64 `yaml:"temperature,omitempty"`
+	MaxTokens   int      `yaml:"max_tokens,omitempty"`
+	Stop        []string `yaml:"stop,omitempty"`
+}
+
+// ToModelOptions converts the YAML-configured sampling parameters into the
+// model.Option values passed to every Generate/Stream call for this model.
+func (p Parameters) ToModelOptions() []model.Option {
+	var opts []model.Option
+	if p.Temperature != nil {
+		opts = append(opts, model.WithTemperature(float32(*p.Temperature)))
+	}
+	if p.MaxTokens > 0 {
+		opts = append(opts, model.WithMaxTokens(p.MaxTokens))
+	}
+	if len(p.Stop) > 0 {
+		opts = append(opts, model.WithStop(p.Stop))
+	}
+	return opts
+}
+
+// Template holds the Go text/template source for each message role Eino's
+// prompt.FromMessages chain is built from.
+type Template struct {
+	System    string `yaml:"system"`
+	User      string `yaml:"user,omitempty"`
+	Assistant string `yaml:"assistant,omitempty"`
+}
+
+// Model is one models/<name>.yaml file: everything needed to construct the
+// BaseChatModel and the chain around it.
+type Model struct {
+	Name        string           `yaml:"name"`
+	Backend     providers.Config `yaml:"backend"`
+	Parameters  Parameters       `yaml:"parameters"`
+	Template    Template         `yaml:"template"`
+	ContextSize int              `yaml:"context_size"`
+}
+
+// Config is the fully-loaded result: the global settings plus every model
+// found in ModelsDir, keyed by Model.Name.
+type Config struct {
+	Global Global
+	Models map[string]*Model
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// withDefaultOptions wraps a model.BaseChatModel so every Generate/Stream
+// call is made with this model's configured Parameters applied, without
+// every caller having to know or pass them explicitly.
+type withDefaultOptions struct {
+	model.BaseChatModel
+	defaults []model.Option
+}
+
+// WrapWithDefaults applies m's Parameters as default model.Option values on
+// every call to base.
+func (m *Model) WrapWithDefaults(base model.BaseChatModel) model.BaseChatModel {
+	defaults := m.Parameters.ToModelOptions()
+	if len(defaults) == 0 {
+		return base
+	}
+	return &withDefaultOptions{BaseChatModel: base, defaults: defaults}
+}
+
+func (w *withDefaultOptions) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return w.BaseChatModel.Generate(ctx, input, append(append([]model.Option{}, w.defaults...), opts...)...)
+}
+
+func (w *withDefaultOptions) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return w.BaseChatModel.Stream(ctx, input, append(append([]model.Option{}, w.defaults...), opts...)...)
+}
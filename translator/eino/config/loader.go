@@ -0,0 +1,122 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Loader reads a config.yaml and its models/ directory from disk and keeps
+// the most recently loaded Config available for concurrent readers,
+// refreshing it on demand (Reload) or automatically on SIGHUP (Watch).
+type Loader struct {
+	configPath string
+
+	mu  sync.RWMutex
+	cfg *Config
+}
+
+// NewLoader creates a Loader for the config.yaml at configPath. Call Load
+// before Current will return anything.
+func NewLoader(configPath string) *Loader {
+	return &Loader{configPath: configPath}
+}
+
+// Load reads config.yaml and every models/*.yaml file, replacing the
+// Loader's current Config on success. The previous Config is left in place
+// if loading fails, so a bad edit to a model file doesn't take the service
+// down.
+func (l *Loader) Load() (*Config, error) {
+	data, err := os.ReadFile(l.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %v", l.configPath, err)
+	}
+
+	var global Global
+	if err := yaml.Unmarshal(data, &global); err != nil {
+		return nil, fmt.Errorf("config: failed to parse %s: %v", l.configPath, err)
+	}
+	if global.ModelsDir == "" {
+		global.ModelsDir = "models"
+	}
+
+	modelsDir := global.ModelsDir
+	if !filepath.IsAbs(modelsDir) {
+		modelsDir = filepath.Join(filepath.Dir(l.configPath), modelsDir)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(modelsDir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to list %s: %v", modelsDir, err)
+	}
+
+	models := make(map[string]*Model, len(paths))
+	for _, path := range paths {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config: failed to read %s: %v", path, err)
+		}
+		var m Model
+		if err := yaml.Unmarshal(raw, &m); err != nil {
+			return nil, fmt.Errorf("config: failed to parse %s: %v", path, err)
+		}
+		if m.Name == "" {
+			m.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		models[m.Name] = &m
+	}
+
+	if global.DefaultModel == "" {
+		for name := range models {
+			global.DefaultModel = name
+			break
+		}
+	}
+	if _, ok := models[global.DefaultModel]; global.DefaultModel != "" && !ok {
+		return nil, fmt.Errorf("config: default_model %q has no matching file under %s", global.DefaultModel, modelsDir)
+	}
+
+	cfg := &Config{Global: global, Models: models}
+
+	l.mu.Lock()
+	l.cfg = cfg
+	l.mu.Unlock()
+
+	return cfg, nil
+}
+
+// Current returns the most recently loaded Config, or nil if Load hasn't
+// succeeded yet.
+func (l *Loader) Current() *Config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg
+}
+
+// Watch reloads the config whenever the process receives SIGHUP, calling
+// onReload with the result of every attempt (including failed ones, so the
+// caller can log them). It blocks until ctx is canceled.
+func (l *Loader) Watch(ctx context.Context, onReload func(*Config, error)) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			cfg, err := l.Load()
+			if onReload != nil {
+				onReload(cfg, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestLoaderLoadsModelsDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.yaml"), "default_model: primary\nmodels_dir: models\n")
+	writeFile(t, filepath.Join(dir, "models", "primary.yaml"), "backend:\n  provider: openai\n  model: gpt-4\ntemplate:\n  system: \"you are primary\"\n")
+
+	cfg, err := NewLoader(filepath.Join(dir, "config.yaml")).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.Global.DefaultModel != "primary" {
+		t.Errorf("DefaultModel = %q, want %q", cfg.Global.DefaultModel, "primary")
+	}
+	m, ok := cfg.Models["primary"]
+	if !ok {
+		t.Fatal("models map missing \"primary\"")
+	}
+	if m.Backend.Provider != "openai" || m.Template.System != "you are primary" {
+		t.Errorf("got model %+v, want provider openai and the configured system template", m)
+	}
+}
+
+func TestLoaderDerivesModelNameFromFilename(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.yaml"), "models_dir: models\n")
+	writeFile(t, filepath.Join(dir, "models", "unnamed.yaml"), "backend:\n  provider: ollama\n")
+
+	cfg, err := NewLoader(filepath.Join(dir, "config.yaml")).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if _, ok := cfg.Models["unnamed"]; !ok {
+		t.Fatalf("expected model name derived from filename \"unnamed\", got %+v", cfg.Models)
+	}
+}
+
+func TestLoaderDefaultsModelsDirToModels(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.yaml"), "default_model: only\n")
+	writeFile(t, filepath.Join(dir, "models", "only.yaml"), "backend:\n  provider: openai\n")
+
+	cfg, err := NewLoader(filepath.Join(dir, "config.yaml")).Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if _, ok := cfg.Models["only"]; !ok {
+		t.Fatalf("expected models_dir to default to \"models\", got %+v", cfg.Models)
+	}
+}
+
+func TestLoaderRejectsUnknownDefaultModel(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "config.yaml"), "default_model: missing\nmodels_dir: models\n")
+	writeFile(t, filepath.Join(dir, "models", "present.yaml"), "backend:\n  provider: openai\n")
+
+	if _, err := NewLoader(filepath.Join(dir, "config.yaml")).Load(); err == nil {
+		t.Error("Load with an unknown default_model = nil error, want an error")
+	}
+}
+
+func TestLoaderKeepsPreviousConfigOnFailedReload(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	writeFile(t, configPath, "default_model: primary\nmodels_dir: models\n")
+	writeFile(t, filepath.Join(dir, "models", "primary.yaml"), "backend:\n  provider: openai\n")
+
+	l := NewLoader(configPath)
+	if _, err := l.Load(); err != nil {
+		t.Fatalf("initial Load returned error: %v", err)
+	}
+
+	writeFile(t, configPath, "default_model: ghost\nmodels_dir: models\n")
+	if _, err := l.Load(); err == nil {
+		t.Fatal("reload with an unknown default_model = nil error, want an error")
+	}
+
+	if got := l.Current(); got == nil || got.Global.DefaultModel != "primary" {
+		t.Errorf("Current() = %+v, want the previous config to be kept after a failed reload", got)
+	}
+}
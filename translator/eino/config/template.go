@@ -0,0 +1,28 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// RenderSystemPrompt executes the model's configured system template. The
+// template has no input variables (it's a straight replacement for the
+// previous hardcoded string), but using text/template leaves room for
+// variables without another format change. Retrieved RAG context is not
+// one of them: the RAG chain appends its own {{.Context}} placeholder to
+// the prompt rendered here.
+func (m *Model) RenderSystemPrompt() (string, error) {
+	if m.Template.System == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(m.Name + ":system").Parse(m.Template.System)
+	if err != nil {
+		return "", fmt.Errorf("config: invalid system template for model %q: %v", m.Name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		return "", fmt.Errorf("config: failed to render system template for model %q: %v", m.Name, err)
+	}
+	return buf.String(), nil
+}
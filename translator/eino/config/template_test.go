@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestRenderSystemPromptEmptyTemplate(t *testing.T) {
+	m := &Model{Name: "test"}
+	got, err := m.RenderSystemPrompt()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "" {
+		t.Errorf("got %q, want empty string for an unset template", got)
+	}
+}
+
+func TestRenderSystemPromptPlainText(t *testing.T) {
+	m := &Model{Name: "test", Template: Template{System: "you are a helpful assistant"}}
+	got, err := m.RenderSystemPrompt()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "you are a helpful assistant" {
+		t.Errorf("got %q, want %q", got, "you are a helpful assistant")
+	}
+}
+
+func TestRenderSystemPromptInvalidTemplate(t *testing.T) {
+	m := &Model{Name: "test", Template: Template{System: "{{.Broken"}}
+	if _, err := m.RenderSystemPrompt(); err == nil {
+		t.Error("RenderSystemPrompt with an invalid template = nil error, want an error")
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	// Eino framework imports
@@ -14,19 +18,71 @@ import (
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/luomsis/agent4everything/translator/eino/agent"
+	"github.com/luomsis/agent4everything/translator/eino/agent/toolbox"
+	"github.com/luomsis/agent4everything/translator/eino/config"
+	"github.com/luomsis/agent4everything/translator/eino/openaiapi"
+	"github.com/luomsis/agent4everything/translator/eino/providers"
+	"github.com/luomsis/agent4everything/translator/eino/rag"
+	"github.com/luomsis/agent4everything/translator/eino/router"
+	"github.com/luomsis/agent4everything/translator/eino/session"
 )
 
+// defaultSystemPrompt is the hardcoded system message createEinoChatChain
+// uses when no config.yaml template applies.
+const defaultSystemPrompt = "You are an intelligent assistant built with CloudWeGo Eino framework. " +
+	"You help developers understand and use the Eino framework for building LLM applications. " +
+	"Provide clear, technical explanations about the framework's capabilities and best practices."
+
+// ragContextSuffix turns a plain system prompt into a RAG chain's
+// systemTemplate by appending the {{.Context}} placeholder the RAG
+// retrieval node fills in with whatever documents were retrieved.
+const ragContextSuffix = "\n\nUse the following retrieved context if it's relevant:\n{{.Context}}"
+
+// ragSystemTemplate is the fallback used when the resolved backend has no
+// YAML-configured system prompt of its own — see ragChainForCollection.
+const ragSystemTemplate = defaultSystemPrompt + ragContextSuffix
+
 // IntelligentChatAssistant represents our Eino-based chat service
 type IntelligentChatAssistant struct {
 	chatModel model.BaseChatModel
 	chatChain compose.Runnable[[]*schema.Message, *schema.Message]
+	toolModel model.ToolCallingChatModel // nil unless the configured provider supports tool calling
+	toolbox   *toolbox.Registry
+
+	cfgLoader *config.Loader // nil unless a config.yaml was found at startup
+
+	routerMu sync.RWMutex
+	router   *router.Router // nil when PROVIDER is unset and no config.yaml was loaded (the demo MockChatModel then serves requests)
+
+	sessions     session.Store        // nil disables session_id support entirely
+	trimStrategy session.TrimStrategy
+	contextSize  int
+
+	ragStore    rag.VectorStore // nil disables the "collection" request option entirely
+	ragEmbedder rag.Embedder
+	ragIngestor *rag.Ingestor
+	ragTopK     int
+
+	pendingMu    sync.Mutex
+	pendingCalls map[string]*pendingToolCall // keyed by the id returned in the "pending" AgentAction
+}
+
+// pendingToolCall is a paused (auto_execute=false) agent loop awaiting the
+// caller's tool results before agent.RunWithResults can resume it.
+type pendingToolCall struct {
+	History []*schema.Message
+	Pending *schema.Message
+	Tools   *toolbox.Registry
 }
 
 // NewIntelligentChatAssistant creates a new chat assistant using Eino framework
 func NewIntelligentChatAssistant(ctx context.Context) (*IntelligentChatAssistant, error) {
-	// Create a mock chat model for demonstration
-	// In production, this would be a real LLM model like OpenAI, etc.
-	chatModel := &MockChatModel{}
+	chatModel, err := newConfiguredChatModel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure chat model: %v", err)
+	}
 
 	// Create Eino chat chain with proper composition
 	chatChain, err := createEinoChatChain(ctx, chatModel)
@@ -34,10 +90,393 @@ func NewIntelligentChatAssistant(ctx context.Context) (*IntelligentChatAssistant
 		return nil, fmt.Errorf("failed to create Eino chat chain: %v", err)
 	}
 
-	return &IntelligentChatAssistant{
-		chatModel: chatModel,
-		chatChain: chatChain,
-	}, nil
+	toolModel, _ := chatModel.(model.ToolCallingChatModel)
+
+	sessions, err := session.NewStoreFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure session store: %v", err)
+	}
+	trimStrategy, err := session.NewTrimStrategyFromEnv(chatModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure session trim strategy: %v", err)
+	}
+
+	ragStore, ragEmbedder, ragTopK, err := ragFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure RAG: %v", err)
+	}
+
+	assistant := &IntelligentChatAssistant{
+		chatModel:    chatModel,
+		chatChain:    chatChain,
+		toolModel:    toolModel,
+		toolbox:      toolbox.Default(),
+		sessions:     sessions,
+		trimStrategy: trimStrategy,
+		contextSize:  contextSizeFromEnv(),
+		ragStore:     ragStore,
+		ragEmbedder:  ragEmbedder,
+		ragTopK:      ragTopK,
+		pendingCalls: make(map[string]*pendingToolCall),
+	}
+	if ragStore != nil && ragEmbedder != nil {
+		assistant.ragIngestor = &rag.Ingestor{
+			Store:         ragStore,
+			Embedder:      ragEmbedder,
+			ChunkTokens:   ragChunkTokensFromEnv(),
+			OverlapTokens: ragOverlapTokensFromEnv(),
+		}
+	}
+
+	if specs := routerSpecsFromEnv(); len(specs) > 0 {
+		rtr, err := router.New(router.Strategy(routerStrategyFromEnv()), specs, createEinoChatChain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure router: %v", err)
+		}
+		assistant.setRouter(rtr)
+	}
+
+	// A config.yaml, when present, takes over model/prompt configuration
+	// from the PROVIDER/MODEL/ROUTER_* environment variables above: it
+	// defines the full set of routable models and their templates.
+	if configPath := configPathFromEnv(); fileExists(configPath) {
+		loader := config.NewLoader(configPath)
+		cfg, err := loader.Load()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %v", configPath, err)
+		}
+		if err := assistant.applyConfig(cfg); err != nil {
+			return nil, fmt.Errorf("failed to configure router from %s: %v", configPath, err)
+		}
+		assistant.cfgLoader = loader
+	}
+
+	return assistant, nil
+}
+
+// setRouter atomically swaps the router in use, so an in-flight request
+// reading the old router isn't disrupted by a concurrent SIGHUP reload.
+func (ica *IntelligentChatAssistant) setRouter(r *router.Router) {
+	ica.routerMu.Lock()
+	ica.router = r
+	ica.routerMu.Unlock()
+}
+
+func (ica *IntelligentChatAssistant) getRouter() *router.Router {
+	ica.routerMu.RLock()
+	defer ica.routerMu.RUnlock()
+	return ica.router
+}
+
+// storePending records a paused (auto_execute=false) agent loop under a
+// freshly generated id, returning that id so the caller can report it to
+// the client as the handle to resume with.
+func (ica *IntelligentChatAssistant) storePending(history []*schema.Message, pending *schema.Message, tools *toolbox.Registry) string {
+	id := fmt.Sprintf("pending-%d", time.Now().UnixNano())
+	ica.pendingMu.Lock()
+	ica.pendingCalls[id] = &pendingToolCall{History: history, Pending: pending, Tools: tools}
+	ica.pendingMu.Unlock()
+	return id
+}
+
+// takePending removes and returns the pending tool-call round stored under
+// id, so each confirmation can only be resumed once.
+func (ica *IntelligentChatAssistant) takePending(id string) (*pendingToolCall, bool) {
+	ica.pendingMu.Lock()
+	defer ica.pendingMu.Unlock()
+	p, ok := ica.pendingCalls[id]
+	if ok {
+		delete(ica.pendingCalls, id)
+	}
+	return p, ok
+}
+
+// applyConfig rebuilds the router from a freshly (re)loaded Config and
+// swaps it in. Used both at startup and by the SIGHUP watch loop in main().
+func (ica *IntelligentChatAssistant) applyConfig(cfg *config.Config) error {
+	rtr, err := buildRouterFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to build router from config: %v", err)
+	}
+	ica.setRouter(rtr)
+	return nil
+}
+
+// contextSizeFromEnv reads SESSION_CONTEXT_SIZE, the token budget the
+// session trim strategies prune history to, defaulting to 4096.
+func contextSizeFromEnv() int {
+	if v := os.Getenv("SESSION_CONTEXT_SIZE"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 4096
+}
+
+// ragFromEnv builds the RAG subsystem's VectorStore, Embedder, and topK from
+// the environment. RAG is opt-in: it's left unconfigured (nil, nil store)
+// unless RAG_ENABLED=true, so a deployment that never sets a "collection"
+// pays nothing for it and doesn't need an embeddings credential.
+func ragFromEnv() (rag.VectorStore, rag.Embedder, int, error) {
+	if os.Getenv("RAG_ENABLED") != "true" {
+		return nil, nil, 0, nil
+	}
+
+	store, err := rag.NewStoreFromEnv()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	embedder, err := rag.NewEmbedderFromEnv()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return store, embedder, ragTopKFromEnv(), nil
+}
+
+// ragTopKFromEnv reads RAG_TOP_K, the number of documents the retrieval
+// node pulls in per query, defaulting to 4.
+func ragTopKFromEnv() int {
+	if v := os.Getenv("RAG_TOP_K"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 4
+}
+
+// ragChunkTokensFromEnv reads RAG_CHUNK_TOKENS, the approximate chunk size
+// the ingestion API splits documents into, defaulting to 200.
+func ragChunkTokensFromEnv() int {
+	if v := os.Getenv("RAG_CHUNK_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 200
+}
+
+// ragOverlapTokensFromEnv reads RAG_OVERLAP_TOKENS, defaulting to 20.
+func ragOverlapTokensFromEnv() int {
+	if v := os.Getenv("RAG_OVERLAP_TOKENS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return 20
+}
+
+// ragChainForCollection builds a one-off Eino chain that retrieves from
+// collection before invoking chatModel, per createEinoChatChainForModel's
+// pattern but with rag.BuildChain's retrieval node spliced in front of the
+// chat model. It's built fresh per call rather than cached alongside
+// ica.chatChain/the router's backends, since collection is a per-request
+// choice, not a per-backend one.
+//
+// backendName identifies which router backend chatModel came from (see
+// modelForOverride), so a request that selects both a router backend and a
+// collection still gets that backend's own YAML-configured system prompt
+// instead of silently falling back to ragSystemTemplate's hardcoded one.
+func (ica *IntelligentChatAssistant) ragChainForCollection(ctx context.Context, chatModel model.BaseChatModel, backendName, collection string) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	if ica.ragStore == nil || ica.ragEmbedder == nil {
+		return nil, fmt.Errorf("rag: collection %q requested but RAG is not configured (set RAG_ENABLED=true)", collection)
+	}
+	retriever := &rag.Retriever{
+		Store:      ica.ragStore,
+		Embedder:   ica.ragEmbedder,
+		Collection: collection,
+		TopK:       ica.ragTopK,
+	}
+	systemTemplate := ragSystemTemplate
+	if rtr := ica.getRouter(); rtr != nil {
+		if sp := rtr.SystemPromptFor(backendName); sp != "" {
+			systemTemplate = sp + ragContextSuffix
+		}
+	}
+	return rag.BuildChain(ctx, chatModel, retriever, systemTemplate)
+}
+
+// sessionTurn loads sessionID's prior history (if sessions are configured
+// and sessionID was given), appends the new user message, and trims the
+// result to ica.contextSize. It returns the messages to send to the model,
+// the (untrimmed-of-the-reply) history for persistTurn to finish off, and
+// the session id to report back to the caller (generated if sessionID was
+// empty). Returns a nil history and empty id when sessions aren't
+// configured, in which case persistTurn is a no-op.
+func (ica *IntelligentChatAssistant) sessionTurn(ctx context.Context, sessionID, userMessage string) (messages []*schema.Message, history []session.Message, resolvedID string, err error) {
+	userTurn := session.NewMessage(&schema.Message{Role: schema.User, Content: userMessage})
+	if ica.sessions == nil {
+		return []*schema.Message{userTurn.Message}, nil, "", nil
+	}
+
+	if sessionID == "" {
+		sessionID = session.NewID()
+	}
+	sess, err := ica.sessions.Get(ctx, sessionID)
+	if err != nil && err != session.ErrNotFound {
+		return nil, nil, "", err
+	}
+	if sess != nil {
+		history = sess.Messages
+	}
+	history = append(append([]session.Message(nil), history...), userTurn)
+
+	history, err = ica.trimStrategy.Trim(ctx, history, ica.contextSize)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return session.Unwrap(history), history, sessionID, nil
+}
+
+// persistTurn appends the assistant's reply to history and writes the
+// result back to the session store. A no-op when sessions aren't
+// configured for this turn (sessionID == "").
+func (ica *IntelligentChatAssistant) persistTurn(ctx context.Context, sessionID string, history []session.Message, reply *schema.Message) error {
+	if ica.sessions == nil || sessionID == "" {
+		return nil
+	}
+	history = append(history, session.NewMessage(reply))
+	return ica.sessions.Replace(ctx, sessionID, history)
+}
+
+// configPathFromEnv returns CONFIG_PATH, defaulting to "config.yaml" in the
+// working directory.
+func configPathFromEnv() string {
+	if p := os.Getenv("CONFIG_PATH"); p != "" {
+		return p
+	}
+	return "config.yaml"
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// buildRouterFromConfig turns a loaded YAML config into a Router with one
+// backend per configured model, each compiled with that model's own prompt
+// template and sampling Parameters via BackendSpec.Build/Wrap. The default
+// model is given Priority 0 so it's preferred under the (default) priority
+// strategy.
+func buildRouterFromConfig(cfg *config.Config) (*router.Router, error) {
+	if len(cfg.Models) == 0 {
+		return nil, fmt.Errorf("config: no models found under %s", cfg.Global.ModelsDir)
+	}
+
+	specs := make([]router.BackendSpec, 0, len(cfg.Models))
+	for name, m := range cfg.Models {
+		m := m // capture for the closure below
+		priority := 1
+		if name == cfg.Global.DefaultModel {
+			priority = 0
+		}
+		systemPrompt, err := m.RenderSystemPrompt()
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, router.BackendSpec{
+			Name:         name,
+			Config:       m.Backend,
+			Priority:     priority,
+			Wrap:         m.WrapWithDefaults,
+			SystemPrompt: systemPrompt,
+			Build: func(ctx context.Context, chatModel model.BaseChatModel) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+				return createEinoChatChainForModel(ctx, chatModel, m)
+			},
+		})
+	}
+
+	return router.New(router.StrategyPriority, specs, createEinoChatChain)
+}
+
+// createEinoChatChainForModel is createEinoChatChain's config-driven
+// counterpart: the system message comes from the model's YAML template
+// instead of the hardcoded string.
+func createEinoChatChainForModel(ctx context.Context, chatModel model.BaseChatModel, m *config.Model) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	systemContent, err := m.RenderSystemPrompt()
+	if err != nil {
+		return nil, err
+	}
+	if systemContent == "" {
+		return createEinoChatChain(ctx, chatModel)
+	}
+
+	systemPrompt := prompt.FromMessages(schema.FString, schema.SystemMessage(systemContent))
+
+	chain := compose.NewChain[[]*schema.Message, *schema.Message]()
+	chain.AppendChatTemplate(systemPrompt)
+	chain.AppendChatModel(chatModel)
+
+	compiledChain, err := chain.Compile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile Eino chain for model %q: %v", m.Name, err)
+	}
+	return compiledChain, nil
+}
+
+// newConfiguredChatModel selects the BaseChatModel implementation to run
+// against. When PROVIDER is unset we fall back to MockChatModel so the
+// service still runs out of the box without any credentials configured;
+// otherwise it builds a real provider from providers.ConfigFromEnv.
+func newConfiguredChatModel() (model.BaseChatModel, error) {
+	if _, ok := os.LookupEnv("PROVIDER"); !ok {
+		return &MockChatModel{}, nil
+	}
+	return providers.NewFromEnv()
+}
+
+// routerStrategyFromEnv reads ROUTER_STRATEGY, defaulting to "priority".
+func routerStrategyFromEnv() string {
+	if s := os.Getenv("ROUTER_STRATEGY"); s != "" {
+		return s
+	}
+	return string(router.StrategyPriority)
+}
+
+// routerSpecsFromEnv builds the router's backend list from the environment:
+// the primary PROVIDER/MODEL/API_KEY/BASE_URL backend (named by MODEL, or
+// "default"), plus any additional backends described in ROUTER_BACKENDS, a
+// JSON array of {"name","provider","model","api_key","base_url","priority","weight"}
+// objects. Returns nil (no router) when PROVIDER is unset, matching
+// newConfiguredChatModel's demo fallback.
+func routerSpecsFromEnv() []router.BackendSpec {
+	if _, ok := os.LookupEnv("PROVIDER"); !ok {
+		return nil
+	}
+
+	primary := providers.ConfigFromEnv()
+	name := primary.Model
+	if name == "" {
+		name = "default"
+	}
+	specs := []router.BackendSpec{{Name: name, Config: primary, Priority: 0}}
+
+	raw := os.Getenv("ROUTER_BACKENDS")
+	if raw == "" {
+		return specs
+	}
+
+	var extra []struct {
+		Name     string `json:"name"`
+		Provider string `json:"provider"`
+		Model    string `json:"model"`
+		APIKey   string `json:"api_key"`
+		BaseURL  string `json:"base_url"`
+		Priority int    `json:"priority"`
+		Weight   int    `json:"weight"`
+	}
+	if err := json.Unmarshal([]byte(raw), &extra); err != nil {
+		log.Printf("router: ignoring invalid ROUTER_BACKENDS: %v", err)
+		return specs
+	}
+	for _, e := range extra {
+		specs = append(specs, router.BackendSpec{
+			Name:     e.Name,
+			Config:   providers.Config{Provider: e.Provider, Model: e.Model, APIKey: e.APIKey, BaseURL: e.BaseURL},
+			Priority: e.Priority,
+			Weight:   e.Weight,
+		})
+	}
+	return specs
 }
 
 // MockChatModel implements the BaseChatModel interface for demonstration
@@ -73,9 +512,7 @@ func createEinoChatChain(ctx context.Context, chatModel model.BaseChatModel) (co
 	// Create system prompt template
 	systemPrompt := prompt.FromMessages(
 		schema.FString,
-		schema.SystemMessage("You are an intelligent assistant built with CloudWeGo Eino framework. "+
-			"You help developers understand and use the Eino framework for building LLM applications. "+
-			"Provide clear, technical explanations about the framework's capabilities and best practices."),
+		schema.SystemMessage(defaultSystemPrompt),
 	)
 
 	// Create Eino chain: System Prompt -> Chat Model
@@ -147,15 +584,349 @@ func (ica *IntelligentChatAssistant) ProcessMessage(ctx context.Context, userMes
 		},
 	}
 
-	// Use the Eino chain to generate response
-	response, err := ica.chatChain.Invoke(ctx, messages)
+	response, err := ica.ProcessMessages(ctx, messages, "", "")
 	if err != nil {
-		return "", fmt.Errorf("failed to generate response using Eino chain: %v", err)
+		return "", err
 	}
-
 	return response.Content, nil
 }
 
+// ProcessMessages is the full-history counterpart to ProcessMessage, used by
+// callers (like the OpenAI-compatible surface) that already manage their own
+// conversation state and pass the whole message list in, system prompt
+// included. modelOverride, if non-empty, pins the request to that router
+// backend instead of letting the routing strategy pick one; it is ignored
+// when no router is configured. collection, if non-empty, retrieves from
+// that RAG collection before generating, in place of the backend's own
+// chain — see ragChainForCollection.
+func (ica *IntelligentChatAssistant) ProcessMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.Message, error) {
+	rtr := ica.getRouter()
+
+	if collection != "" {
+		chatModel, backendName, err := ica.modelForOverride(modelOverride)
+		if err != nil {
+			return nil, err
+		}
+		chain, err := ica.ragChainForCollection(ctx, chatModel, backendName, collection)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		response, err := chain.Invoke(ctx, messages)
+		if rtr != nil {
+			rtr.Record(backendName, time.Since(start), err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate response from backend %q with collection %q: %v", backendName, collection, err)
+		}
+		return response, nil
+	}
+
+	if rtr == nil {
+		response, err := ica.chatChain.Invoke(ctx, messages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate response using Eino chain: %v", err)
+		}
+		return response, nil
+	}
+
+	chain, backendName, err := rtr.Chain(ctx, modelOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	response, err := chain.Invoke(ctx, messages)
+	rtr.Record(backendName, time.Since(start), err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate response from backend %q: %v", backendName, err)
+	}
+	return response, nil
+}
+
+// modelForOverride resolves modelOverride to a raw BaseChatModel: the named
+// router backend's model if a router is configured, otherwise ica.chatModel
+// (modelOverride is meaningless without a router and is ignored).
+func (ica *IntelligentChatAssistant) modelForOverride(modelOverride string) (model.BaseChatModel, string, error) {
+	rtr := ica.getRouter()
+	if rtr == nil {
+		return ica.chatModel, "default", nil
+	}
+	return rtr.Model(modelOverride)
+}
+
+// StreamMessage behaves like ProcessMessage but returns the Eino chain's
+// stream reader directly, so callers can forward deltas as they arrive.
+func (ica *IntelligentChatAssistant) StreamMessage(ctx context.Context, userMessage string) (*schema.StreamReader[*schema.Message], error) {
+	messages := []*schema.Message{
+		{
+			Role:    schema.User,
+			Content: userMessage,
+		},
+	}
+	return ica.StreamMessages(ctx, messages, "", "")
+}
+
+// StreamMessages is the full-history counterpart to StreamMessage. collection
+// plays the same role as in ProcessMessages.
+func (ica *IntelligentChatAssistant) StreamMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.StreamReader[*schema.Message], error) {
+	if collection != "" {
+		chatModel, backendName, err := ica.modelForOverride(modelOverride)
+		if err != nil {
+			return nil, err
+		}
+		chain, err := ica.ragChainForCollection(ctx, chatModel, backendName, collection)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		stream, err := chain.Stream(ctx, messages)
+		if rtr := ica.getRouter(); rtr != nil {
+			rtr.Record(backendName, time.Since(start), err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to stream response from backend %q with collection %q: %v", backendName, collection, err)
+		}
+		return stream, nil
+	}
+
+	if rtr := ica.getRouter(); rtr != nil {
+		chain, backendName, err := rtr.Chain(ctx, modelOverride)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		stream, err := chain.Stream(ctx, messages)
+		rtr.Record(backendName, time.Since(start), err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stream response from backend %q: %v", backendName, err)
+		}
+		return stream, nil
+	}
+
+	stream, err := ica.chatChain.Stream(ctx, messages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream response using Eino chain: %v", err)
+	}
+	return stream, nil
+}
+
+// sseEvent writes a single SSE "data: <json>\n\n" frame and flushes it
+// immediately so the client sees it without buffering delay.
+func sseEvent(w http.ResponseWriter, flusher http.Flusher, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
+// streamChatResponse honors chatHandler's SSE mode: it forwards each
+// assistant delta as its own event, then a final event carrying token usage
+// (when the provider reported one), then the terminating "data: [DONE]".
+func streamChatResponse(w http.ResponseWriter, r *http.Request, assistant *IntelligentChatAssistant, userMessage, modelOverride, collection, sessionID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	messages, history, sessionID, err := assistant.sessionTurn(r.Context(), sessionID, userMessage)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	stream, err := assistant.StreamMessages(r.Context(), messages, modelOverride, collection)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	if sessionID != "" {
+		sseEvent(w, flusher, map[string]string{"session_id": sessionID})
+	}
+
+	var usage interface{}
+	var reply strings.Builder
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			sseEvent(w, flusher, map[string]string{"error": err.Error()})
+			fmt.Fprint(w, "data: [DONE]\n\n")
+			flusher.Flush()
+			return
+		}
+		if u, ok := msg.Extra["usage"]; ok {
+			usage = u
+		}
+		if msg.Content == "" {
+			continue
+		}
+		reply.WriteString(msg.Content)
+		if err := sseEvent(w, flusher, map[string]string{"delta": msg.Content}); err != nil {
+			return
+		}
+	}
+
+	if err := assistant.persistTurn(r.Context(), sessionID, history, &schema.Message{Role: schema.Assistant, Content: reply.String()}); err != nil {
+		log.Printf("failed to persist session %q: %v", sessionID, err)
+	}
+
+	if usage != nil {
+		sseEvent(w, flusher, map[string]interface{}{"usage": usage})
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// agentChatHandler runs a tool-calling agent loop for a /chat request that
+// requested tools, streaming AgentAction events over SSE as the loop
+// progresses (tool calls, tool results, and the terminal message).
+func agentChatHandler(w http.ResponseWriter, r *http.Request, assistant *IntelligentChatAssistant, userMessage string, toolNames []string, autoExecute bool) {
+	if assistant.toolModel == nil {
+		http.Error(w, "configured provider does not support tool calling", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	tools, err := assistant.toolbox.Subset(toolNames)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	assistantAgent, err := agent.New(r.Context(), assistant.toolModel, tools, autoExecute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	events := make(chan agent.AgentAction)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for action := range events {
+			sseEvent(w, flusher, action)
+		}
+	}()
+
+	history := []*schema.Message{{Role: schema.User, Content: userMessage}}
+	msg, err := assistantAgent.Run(r.Context(), history, events)
+	if err == nil && !autoExecute && msg != nil && len(msg.ToolCalls) > 0 {
+		id := assistant.storePending(history, msg, tools)
+		events <- agent.AgentAction{Type: "pending", ID: id}
+	}
+	close(events)
+	<-done
+
+	if err != nil {
+		sseEvent(w, flusher, map[string]string{"error": err.Error()})
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	flusher.Flush()
+}
+
+// chatToolResultsHandler implements POST /chat/{id}/tool_results, resuming
+// a paused (auto_execute=false) agent loop: id is the confirmation id
+// agentChatHandler reported in its "pending" action, and the request body
+// supplies the caller-confirmed result for each pending tool call, keyed by
+// tool_call id. It streams the resumed loop's events the same way
+// agentChatHandler does.
+func chatToolResultsHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
+	const suffix = "/tool_results"
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/chat/")
+		if !strings.HasSuffix(path, suffix) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		id := strings.TrimSuffix(path, suffix)
+		if id == "" {
+			http.Error(w, "pending id is required", http.StatusBadRequest)
+			return
+		}
+
+		pending, ok := assistant.takePending(id)
+		if !ok {
+			http.Error(w, "no pending tool calls for that id", http.StatusNotFound)
+			return
+		}
+
+		var request struct {
+			Results map[string]string `json:"results"` // tool_call id -> result content
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming not supported", http.StatusInternalServerError)
+			return
+		}
+
+		// RunWithResults executes tools automatically from here on; the
+		// caller already confirmed the pending round, so there's nothing
+		// left to pause for.
+		assistantAgent, err := agent.New(r.Context(), assistant.toolModel, pending.Tools, true)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		events := make(chan agent.AgentAction)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for action := range events {
+				sseEvent(w, flusher, action)
+			}
+		}()
+
+		_, err = assistantAgent.RunWithResults(r.Context(), pending.History, pending.Pending, request.Results, events)
+		close(events)
+		<-done
+
+		if err != nil {
+			sseEvent(w, flusher, map[string]string{"error": err.Error()})
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+		flusher.Flush()
+	}
+}
+
 // HTTP handler for chat endpoint
 func chatHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -165,7 +936,12 @@ func chatHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
 		}
 
 		var request struct {
-			Message string `json:"message"`
+			Message     string   `json:"message"`
+			Model       string   `json:"model"` // optional router backend override
+			Collection  string   `json:"collection"` // optional RAG collection to retrieve from before generating
+			SessionID   string   `json:"session_id"`
+			Tools       []string `json:"tools"`
+			AutoExecute *bool    `json:"auto_execute"`
 		}
 
 		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -173,21 +949,236 @@ func chatHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
 			return
 		}
 
-		response, err := assistant.ProcessMessage(r.Context(), request.Message)
+		if len(request.Tools) > 0 {
+			autoExecute := true
+			if request.AutoExecute != nil {
+				autoExecute = *request.AutoExecute
+			}
+			agentChatHandler(w, r, assistant, request.Message, request.Tools, autoExecute)
+			return
+		}
+
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			streamChatResponse(w, r, assistant, request.Message, request.Model, request.Collection, request.SessionID)
+			return
+		}
+
+		messages, history, sessionID, err := assistant.sessionTurn(r.Context(), request.SessionID, request.Message)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(map[string]interface{}{
-			"response":  response,
+		response, err := assistant.ProcessMessages(r.Context(), messages, request.Model, request.Collection)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := assistant.persistTurn(r.Context(), sessionID, history, response); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		respBody := map[string]interface{}{
+			"response":  response.Content,
 			"framework": "cloudwego/eino",
 			"version":   "v0.5.2",
-		})
+		}
+		if sessionID != "" {
+			respBody["session_id"] = sessionID
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(respBody)
+	}
+}
+
+// routerStateHandler implements GET /router/state, reporting per-backend
+// health and latency. Returns an empty list when no router is configured
+// (e.g. the demo MockChatModel is in use).
+func routerStateHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state := []router.State{}
+		if rtr := assistant.getRouter(); rtr != nil {
+			state = rtr.State()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"backends": state})
 	}
 }
 
+// modelsHandler implements GET /v1/models. When assistant was started with a
+// config.yaml, it lists every currently loaded model (reflecting the most
+// recent SIGHUP reload); otherwise it falls back to the single static
+// fallback model describing the PROVIDER/MODEL env configuration.
+func modelsHandler(assistant *IntelligentChatAssistant, fallback openaiapi.Model) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		models := []openaiapi.Model{fallback}
+		if assistant.cfgLoader != nil {
+			if cfg := assistant.cfgLoader.Current(); cfg != nil {
+				models = make([]openaiapi.Model, 0, len(cfg.Models))
+				for name := range cfg.Models {
+					models = append(models, openaiapi.Model{ID: name, Object: "model", Created: time.Now().Unix(), OwnedBy: "self-hosted"})
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openaiapi.ModelList{Object: "list", Data: models})
+	}
+}
+
+// sessionsHandler implements GET/DELETE /sessions/{id} and POST
+// /sessions/{id}/summarize, dispatching on the trailing path segment since
+// the service otherwise has no need for a path-parameter-aware router.
+func sessionsHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if assistant.sessions == nil {
+			http.Error(w, "session storage is not configured", http.StatusNotImplemented)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/sessions/")
+		if path == "" {
+			http.Error(w, "session id is required", http.StatusBadRequest)
+			return
+		}
+
+		if strings.HasSuffix(path, "/summarize") {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			summarizeSessionHandler(w, r, assistant, strings.TrimSuffix(path, "/summarize"))
+			return
+		}
+
+		id := path
+		switch r.Method {
+		case http.MethodGet:
+			sess, err := assistant.sessions.Get(r.Context(), id)
+			if err == session.ErrNotFound {
+				http.Error(w, "session not found", http.StatusNotFound)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(sess)
+		case http.MethodDelete:
+			if err := assistant.sessions.Delete(r.Context(), id); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// summarizeSessionHandler compacts a session's history on demand: the
+// older turns (everything before the last few) are replaced with a single
+// summary message, regardless of whether the conversation currently
+// exceeds the configured context size.
+func summarizeSessionHandler(w http.ResponseWriter, r *http.Request, assistant *IntelligentChatAssistant, id string) {
+	sess, err := assistant.sessions.Get(r.Context(), id)
+	if err == session.ErrNotFound {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	const keepMessages = 6
+	summarizer := session.SummarizeTrim{Model: assistant.chatModel, Keep: keepMessages}
+	// contextSize -1 forces summarization regardless of the session's
+	// current size, since this endpoint is an explicit "compact now" request.
+	summarized, err := summarizer.Trim(r.Context(), sess.Messages, -1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := assistant.sessions.Replace(r.Context(), id, summarized); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&session.Session{ID: id, Messages: summarized})
+}
+
+// ragDocumentsHandler implements POST /rag/documents and DELETE
+// /rag/documents/{id}, the ingestion API for the collections RAG retrieval
+// reads from. Both require a ?collection= query parameter, mirroring how
+// chatHandler takes its collection from the request body rather than the
+// path: there's no natural path segment for it here since POST doesn't
+// name a document yet.
+func ragDocumentsHandler(assistant *IntelligentChatAssistant) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if assistant.ragIngestor == nil {
+			http.Error(w, "RAG is not configured (set RAG_ENABLED=true)", http.StatusNotImplemented)
+			return
+		}
+
+		collection := r.URL.Query().Get("collection")
+		if collection == "" {
+			http.Error(w, "collection is required", http.StatusBadRequest)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			ingestDocumentHandler(w, r, assistant, collection)
+		case http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/rag/documents/")
+			if id == "" {
+				http.Error(w, "document id is required", http.StatusBadRequest)
+				return
+			}
+			if err := assistant.ragStore.DeleteDocument(r.Context(), collection, id); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// ingestDocumentHandler reads the request body as one document (its
+// Content-Type header selects how rag.ExtractText reads it: text/plain,
+// text/markdown, or application/pdf) and ingests it into collection under
+// ?doc_id= (generated if omitted).
+func ingestDocumentHandler(w http.ResponseWriter, r *http.Request, assistant *IntelligentChatAssistant, collection string) {
+	docID := r.URL.Query().Get("doc_id")
+	if docID == "" {
+		docID = fmt.Sprintf("doc-%d", time.Now().UnixNano())
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	chunks, err := assistant.ragIngestor.Ingest(r.Context(), collection, docID, contentType, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"doc_id": docID, "collection": collection, "chunks": chunks})
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -203,8 +1194,37 @@ func main() {
 		Handler: nil,
 	}
 
+	modelName := os.Getenv("MODEL")
+	if modelName == "" {
+		modelName = "mock-model"
+	}
+
 	// Register handlers
 	http.HandleFunc("/chat", chatHandler(assistant))
+	http.HandleFunc("/chat/", chatToolResultsHandler(assistant))
+	http.HandleFunc("/v1/chat/completions", openaiapi.ChatCompletionsHandler(assistant, modelName, openaiapi.NewID))
+	http.HandleFunc("/v1/models", modelsHandler(assistant, openaiapi.Model{ID: modelName, Object: "model", Created: time.Now().Unix(), OwnedBy: "self-hosted"}))
+	http.HandleFunc("/v1/embeddings", openaiapi.EmbeddingsHandler())
+	http.HandleFunc("/router/state", routerStateHandler(assistant))
+	http.HandleFunc("/sessions/", sessionsHandler(assistant))
+	http.HandleFunc("/rag/documents", ragDocumentsHandler(assistant))
+	http.HandleFunc("/rag/documents/", ragDocumentsHandler(assistant))
+
+	// When running off a config.yaml, SIGHUP reloads it and hot-swaps the
+	// router without dropping in-flight requests or restarting the process.
+	if assistant.cfgLoader != nil {
+		go assistant.cfgLoader.Watch(ctx, func(cfg *config.Config, err error) {
+			if err != nil {
+				log.Printf("config reload failed, keeping previous configuration: %v", err)
+				return
+			}
+			if err := assistant.applyConfig(cfg); err != nil {
+				log.Printf("config reload failed, keeping previous configuration: %v", err)
+				return
+			}
+			log.Printf("reloaded configuration from %s", configPathFromEnv())
+		})
+	}
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]string{
@@ -0,0 +1,229 @@
+package openaiapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Backend is the subset of IntelligentChatAssistant the OpenAI-compatible
+// surface needs, kept as an interface so this package doesn't import main.
+type Backend interface {
+	ProcessMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.Message, error)
+	StreamMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.StreamReader[*schema.Message], error)
+}
+
+// writeError writes an OpenAI-shaped {"error": {...}} body.
+func writeError(w http.ResponseWriter, status int, errType, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: errorBody{Message: message, Type: errType}})
+}
+
+// ChatCompletionsHandler implements POST /v1/chat/completions.
+//
+// Known gap: temperature and max_tokens are parsed (ChatCompletionRequest)
+// but rejected here rather than honored, because Backend has no parameter
+// to carry per-request model.Option overrides through to the router/model
+// — only a model's YAML-configured Parameters apply. /chat has no
+// per-request temperature or max_tokens either, so neither endpoint
+// honors them.
+func ChatCompletionsHandler(backend Backend, defaultModel string, idGen func() string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "invalid_request_error", "method not allowed")
+			return
+		}
+
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body")
+			return
+		}
+		if len(req.Messages) == 0 {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "messages is required")
+			return
+		}
+		if len(req.Tools) > 0 || req.ToolChoice != nil || req.FunctionCall != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "tool calling is not supported over /v1/chat/completions; use /chat with \"tools\" instead")
+			return
+		}
+		if req.Temperature != nil || req.MaxTokens != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "temperature and max_tokens are not yet supported over /v1/chat/completions")
+			return
+		}
+
+		modelName := req.Model
+		if modelName == "" {
+			modelName = defaultModel
+		}
+		messages := toSchemaMessages(req.Messages)
+		id := idGen()
+
+		if req.Stream {
+			streamChatCompletions(w, r, backend, messages, id, modelName, req.Model, req.Collection)
+			return
+		}
+
+		resp, err := backend.ProcessMessages(r.Context(), messages, req.Model, req.Collection)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+			return
+		}
+
+		completion := ChatCompletion{
+			ID:      id,
+			Object:  "chat.completion",
+			Created: nowUnix(),
+			Model:   modelName,
+			Choices: []Choice{{
+				Index:        0,
+				Message:      ChatMessage{Role: string(schema.Assistant), Content: resp.Content},
+				FinishReason: "stop",
+			}},
+			Usage: usageFromExtra(resp),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(completion)
+	}
+}
+
+// streamChatCompletions emits "chat.completion.chunk" SSE events, one per
+// model delta, followed by a terminal usage-bearing chunk and "data: [DONE]".
+func streamChatCompletions(w http.ResponseWriter, r *http.Request, backend Backend, messages []*schema.Message, id, modelName, modelOverride, collection string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "server_error", "streaming not supported")
+		return
+	}
+
+	stream, err := backend.StreamMessages(r.Context(), messages, modelOverride, collection)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	defer stream.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	created := nowUnix()
+	first := true
+	var usage *Usage
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// The SSE response is already underway (headers sent, possibly
+			// prior chunks written), so this can't become an HTTP error
+			// response — emit it as its own "data:" frame instead of
+			// falling through to a fake "finish_reason":"stop".
+			writeSSEErrorChunk(w, flusher, err)
+			return
+		}
+
+		delta := ChunkDelta{Content: msg.Content}
+		if first {
+			delta.Role = string(schema.Assistant)
+			first = false
+		}
+		if u := usageFromExtra(msg); u != (Usage{}) {
+			usage = &u
+		}
+
+		writeChunk(w, flusher, ChatCompletionChunk{
+			ID: id, Object: "chat.completion.chunk", Created: created, Model: modelName,
+			Choices: []ChunkChoice{{Index: 0, Delta: delta}},
+		})
+	}
+
+	finish := "stop"
+	writeChunk(w, flusher, ChatCompletionChunk{
+		ID: id, Object: "chat.completion.chunk", Created: created, Model: modelName,
+		Choices: []ChunkChoice{{Index: 0, Delta: ChunkDelta{}, FinishReason: &finish}},
+		Usage:   usage,
+	})
+
+	w.Write([]byte("data: [DONE]\n\n"))
+	flusher.Flush()
+}
+
+func writeChunk(w http.ResponseWriter, flusher http.Flusher, chunk ChatCompletionChunk) {
+	data, _ := json.Marshal(chunk)
+	w.Write([]byte("data: "))
+	w.Write(data)
+	w.Write([]byte("\n\n"))
+	flusher.Flush()
+}
+
+// writeSSEErrorChunk emits an OpenAI-shaped {"error": {...}} "data:" frame
+// followed by "data: [DONE]", for failures discovered mid-stream (after
+// HTTP headers are already committed and a normal error response is no
+// longer possible).
+func writeSSEErrorChunk(w http.ResponseWriter, flusher http.Flusher, err error) {
+	data, _ := json.Marshal(errorResponse{Error: errorBody{Message: err.Error(), Type: "server_error"}})
+	w.Write([]byte("data: "))
+	w.Write(data)
+	w.Write([]byte("\n\ndata: [DONE]\n\n"))
+	flusher.Flush()
+}
+
+// ModelsHandler implements GET /v1/models.
+func ModelsHandler(models []Model) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ModelList{Object: "list", Data: models})
+	}
+}
+
+// EmbeddingsHandler implements POST /v1/embeddings. Embeddings aren't
+// wired to a real provider yet, so this reports a clear, OpenAI-shaped
+// "not implemented" error rather than silently returning zero vectors.
+func EmbeddingsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req EmbeddingsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request_error", "invalid JSON body")
+			return
+		}
+		writeError(w, http.StatusNotImplemented, "server_error", "embeddings are not yet configured for this server")
+	}
+}
+
+func usageFromExtra(msg *schema.Message) Usage {
+	if msg == nil || msg.Extra == nil {
+		return Usage{}
+	}
+	raw, ok := msg.Extra["usage"]
+	if !ok {
+		return Usage{}
+	}
+	// Providers report usage as providers.Usage, which has the same field
+	// names/types as Usage; round-trip through JSON to avoid a dependency
+	// from openaiapi on the providers package for a 3-field struct.
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return Usage{}
+	}
+	var u Usage
+	json.Unmarshal(data, &u)
+	return u
+}
+
+func nowUnix() int64 {
+	return time.Now().Unix()
+}
+
+// NewID returns a unique completion ID, e.g. "chatcmpl-1706198421123456789".
+func NewID() string {
+	return fmt.Sprintf("chatcmpl-%d", time.Now().UnixNano())
+}
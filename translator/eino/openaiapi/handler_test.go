@@ -0,0 +1,103 @@
+package openaiapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+type fakeBackend struct {
+	reply *schema.Message
+	err   error
+}
+
+func (f *fakeBackend) ProcessMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.Message, error) {
+	return f.reply, f.err
+}
+
+func (f *fakeBackend) StreamMessages(ctx context.Context, messages []*schema.Message, modelOverride, collection string) (*schema.StreamReader[*schema.Message], error) {
+	return nil, f.err
+}
+
+func postChatCompletions(t *testing.T, backend Backend, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	ChatCompletionsHandler(backend, "gpt-4", func() string { return "chatcmpl-test" })(rec, req)
+	return rec
+}
+
+func TestChatCompletionsHandlerRequiresMessages(t *testing.T) {
+	rec := postChatCompletions(t, &fakeBackend{}, `{"model":"gpt-4","messages":[]}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestChatCompletionsHandlerRejectsTools(t *testing.T) {
+	body := `{"model":"gpt-4","messages":[{"role":"user","content":"hi"}],"tools":[{"type":"function","function":{"name":"f"}}]}`
+	rec := postChatCompletions(t, &fakeBackend{}, body)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestChatCompletionsHandlerRejectsTemperatureAndMaxTokens(t *testing.T) {
+	body := `{"model":"gpt-4","messages":[{"role":"user","content":"hi"}],"temperature":0.5}`
+	rec := postChatCompletions(t, &fakeBackend{}, body)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	body = `{"model":"gpt-4","messages":[{"role":"user","content":"hi"}],"max_tokens":16}`
+	rec = postChatCompletions(t, &fakeBackend{}, body)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("max_tokens: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestChatCompletionsHandlerHappyPath(t *testing.T) {
+	backend := &fakeBackend{reply: &schema.Message{Role: schema.Assistant, Content: "hello there"}}
+	body := `{"model":"gpt-4","messages":[{"role":"user","content":"hi"}]}`
+	rec := postChatCompletions(t, backend, body)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var got ChatCompletion
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Choices) != 1 || got.Choices[0].Message.Content != "hello there" {
+		t.Errorf("got %+v, want one choice with content %q", got, "hello there")
+	}
+}
+
+func TestChatCompletionsHandlerBackendErrorBecomes500(t *testing.T) {
+	backend := &fakeBackend{err: context.DeadlineExceeded}
+	body := `{"model":"gpt-4","messages":[{"role":"user","content":"hi"}]}`
+	rec := postChatCompletions(t, backend, body)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestModelsHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+	ModelsHandler([]Model{{ID: "gpt-4", Object: "model"}})(rec, req)
+
+	var got ModelList
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got.Data) != 1 || got.Data[0].ID != "gpt-4" {
+		t.Errorf("got %+v, want one model %q", got, "gpt-4")
+	}
+}
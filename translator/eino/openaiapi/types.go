@@ -0,0 +1,138 @@
+// Package openaiapi exposes an OpenAI-compatible HTTP surface
+// (/v1/chat/completions, /v1/models, /v1/embeddings) on top of the
+// service's existing Eino-based chat backend, so unmodified OpenAI SDKs
+// can point their base URL at this server. tools/tool_choice/function_call
+// and temperature/max_tokens are parsed but rejected with a clear error
+// rather than honored (see ChatCompletionsHandler). /chat offers tool
+// calling through its own "tools" field of built-in tool names, but has no
+// per-request temperature or max_tokens.
+package openaiapi
+
+import "github.com/cloudwego/eino/schema"
+
+// ChatMessage mirrors the OpenAI chat message shape.
+type ChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ChatCompletionRequest mirrors POST /v1/chat/completions.
+type ChatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []ChatMessage `json:"messages"`
+	Stream      bool          `json:"stream,omitempty"`
+	Temperature *float64      `json:"temperature,omitempty"`
+	MaxTokens   *int          `json:"max_tokens,omitempty"`
+	Tools       []Tool        `json:"tools,omitempty"`
+	ToolChoice  interface{}   `json:"tool_choice,omitempty"`
+	// FunctionCall is the deprecated predecessor of ToolChoice, accepted
+	// for clients that haven't migrated off it yet.
+	FunctionCall interface{} `json:"function_call,omitempty"`
+	// Collection is a non-standard extension: when set, the RAG retrieval
+	// node runs against this collection before generating, injecting the
+	// retrieved documents into the system prompt. Requests a collection
+	// without RAG configured on the server (RAG_ENABLED=true) fail with a
+	// server_error rather than silently falling back to plain chat.
+	Collection string `json:"collection,omitempty"`
+}
+
+// Tool mirrors the OpenAI "tools" entry: {"type": "function", "function": {...}}.
+type Tool struct {
+	Type     string       `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction describes a callable function offered to the model.
+type ToolFunction struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Parameters  interface{} `json:"parameters,omitempty"`
+}
+
+// Usage mirrors the OpenAI usage block.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Choice is a single completion choice in a non-streaming response.
+type Choice struct {
+	Index        int         `json:"index"`
+	Message      ChatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+// ChatCompletion is the canonical non-streaming "chat.completion" object.
+type ChatCompletion struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"` // always "chat.completion"
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   Usage    `json:"usage"`
+}
+
+// ChunkDelta is the partial content of a streaming choice.
+type ChunkDelta struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// ChunkChoice is a single choice within a streaming chunk.
+type ChunkChoice struct {
+	Index        int        `json:"index"`
+	Delta        ChunkDelta `json:"delta"`
+	FinishReason *string    `json:"finish_reason"`
+}
+
+// ChatCompletionChunk is the canonical "chat.completion.chunk" object sent
+// as each streamed SSE "data:" event.
+type ChatCompletionChunk struct {
+	ID      string        `json:"id"`
+	Object  string        `json:"object"` // always "chat.completion.chunk"
+	Created int64         `json:"created"`
+	Model   string        `json:"model"`
+	Choices []ChunkChoice `json:"choices"`
+	Usage   *Usage        `json:"usage,omitempty"` // only set on the terminal chunk
+}
+
+// Model mirrors a single entry of GET /v1/models.
+type Model struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"` // always "model"
+	Created int64  `json:"created"`
+	OwnedBy string `json:"owned_by"`
+}
+
+// ModelList mirrors the GET /v1/models envelope.
+type ModelList struct {
+	Object string  `json:"object"` // always "list"
+	Data   []Model `json:"data"`
+}
+
+// EmbeddingsRequest mirrors POST /v1/embeddings.
+type EmbeddingsRequest struct {
+	Model string      `json:"model"`
+	Input interface{} `json:"input"` // string or []string
+}
+
+// errorResponse mirrors OpenAI's {"error": {...}} envelope.
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// toSchemaMessages translates OpenAI-shaped messages into Eino's
+// []*schema.Message, the input type ProcessMessages/StreamMessages expect.
+func toSchemaMessages(messages []ChatMessage) []*schema.Message {
+	out := make([]*schema.Message, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, &schema.Message{Role: schema.RoleType(m.Role), Content: m.Content})
+	}
+	return out
+}
@@ -0,0 +1,224 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Anthropic implements model.BaseChatModel against the Claude Messages API.
+type Anthropic struct {
+	cfg    Config
+	client *http.Client
+	apiURL string
+}
+
+// NewAnthropic builds an Anthropic-backed chat model.
+func NewAnthropic(cfg Config, client *http.Client) *Anthropic {
+	base := cfg.BaseURL
+	if base == "" {
+		base = "https://api.anthropic.com/v1"
+	}
+	return &Anthropic{cfg: cfg, client: client, apiURL: strings.TrimRight(base, "/") + "/messages"}
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model         string             `json:"model"`
+	System        string             `json:"system,omitempty"`
+	Messages      []anthropicMessage `json:"messages"`
+	MaxTokens     int                `json:"max_tokens"`
+	Stream        bool               `json:"stream"`
+	Temperature   *float32           `json:"temperature,omitempty"`
+	StopSequences []string           `json:"stop_sequences,omitempty"`
+}
+
+// anthropicDefaultMaxTokens is used when neither the caller nor the
+// configured model.Parameters set MaxTokens; Anthropic requires the field.
+const anthropicDefaultMaxTokens = 4096
+
+type anthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+type anthropicContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type anthropicResponse struct {
+	Content []anthropicContentBlock `json:"content"`
+	Usage   anthropicUsage          `json:"usage"`
+}
+
+// anthropicStreamEvent covers the handful of "event" payloads we care about:
+// message_start (carries input token usage), content_block_delta (text), and
+// message_delta (carries output token usage at the end of the stream).
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type         string `json:"type"`
+		Text         string `json:"text"`
+		OutputTokens int    `json:"output_tokens"`
+	} `json:"delta"`
+	Message struct {
+		Usage anthropicUsage `json:"usage"`
+	} `json:"message"`
+	Usage anthropicUsage `json:"usage"`
+}
+
+func toAnthropicRequest(cfg Config, input []*schema.Message, opts []model.Option) anthropicRequest {
+	req := anthropicRequest{Model: cfg.Model, MaxTokens: anthropicDefaultMaxTokens}
+	for _, m := range input {
+		if m.Role == schema.System {
+			req.System = m.Content
+			continue
+		}
+		role := "user"
+		if m.Role == schema.Assistant {
+			role = "assistant"
+		}
+		req.Messages = append(req.Messages, anthropicMessage{Role: role, Content: m.Content})
+	}
+
+	resolved := resolveOptions(opts)
+	if resolved.MaxTokens != nil {
+		req.MaxTokens = *resolved.MaxTokens
+	}
+	req.Temperature = resolved.Temperature
+	req.StopSequences = resolved.Stop
+	return req
+}
+
+func (a *Anthropic) newRequest(ctx context.Context, body anthropicRequest) (*http.Request, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to marshal anthropic request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to build anthropic request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", a.cfg.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+	return req, nil
+}
+
+// Generate implements model.BaseChatModel.
+func (a *Anthropic) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	req, err := a.newRequest(ctx, toAnthropicRequest(a.cfg, input, opts))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: anthropic request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("providers: anthropic returned status %d", resp.StatusCode)
+	}
+
+	var parsed anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("providers: failed to decode anthropic response: %v", err)
+	}
+
+	var content strings.Builder
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			content.WriteString(block.Text)
+		}
+	}
+
+	out := &schema.Message{Role: schema.Assistant, Content: content.String()}
+	setUsage(out, Usage{
+		PromptTokens:     parsed.Usage.InputTokens,
+		CompletionTokens: parsed.Usage.OutputTokens,
+		TotalTokens:      parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+	})
+	return out, nil
+}
+
+// Stream implements model.BaseChatModel against Anthropic's SSE stream,
+// which uses named "event:"/"data:" pairs rather than OpenAI's bare "data:".
+func (a *Anthropic) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	body := toAnthropicRequest(a.cfg, input, opts)
+	body.Stream = true
+	req, err := a.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: anthropic stream request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("providers: anthropic stream returned status %d", resp.StatusCode)
+	}
+
+	sr, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer resp.Body.Close()
+		defer sw.Close()
+
+		usage := Usage{}
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+			var event anthropicStreamEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				sw.Send(nil, fmt.Errorf("providers: failed to decode anthropic stream event: %v", err))
+				return
+			}
+
+			switch event.Type {
+			case "message_start":
+				usage.PromptTokens = event.Message.Usage.InputTokens
+			case "message_delta":
+				usage.CompletionTokens = event.Usage.OutputTokens
+				usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+			case "content_block_delta":
+				if event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+					continue
+				}
+				msg := &schema.Message{Role: schema.Assistant, Content: event.Delta.Text}
+				if closed := sw.Send(msg, nil); closed {
+					return
+				}
+			case "message_stop":
+				msg := &schema.Message{Role: schema.Assistant, Content: ""}
+				setUsage(msg, usage)
+				sw.Send(msg, nil)
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sw.Send(nil, fmt.Errorf("providers: anthropic stream read failed: %v", err))
+		}
+	}()
+
+	return sr, nil
+}
@@ -0,0 +1,30 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// AzureOpenAI reuses OpenAI's Chat Completions wire format: Azure's
+// "deployments" endpoint accepts the same request/response shapes, it just
+// authenticates with an "api-key" header instead of "Authorization: Bearer".
+type AzureOpenAI struct {
+	*OpenAI
+}
+
+// NewAzureOpenAI builds an Azure OpenAI-backed chat model. Unlike
+// NewOpenAI/NewOllama, there's no sensible default for BaseURL: it must
+// point at the deployment's full base path, e.g.
+// "https://<resource>.openai.azure.com/openai/deployments/<deployment>",
+// so an empty BaseURL is rejected here rather than silently producing a
+// relative apiURL that only fails once a request is made.
+func NewAzureOpenAI(cfg Config, client *http.Client) (*AzureOpenAI, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("providers: azure-openai requires base_url to be set")
+	}
+	o := NewOpenAI(cfg, client)
+	o.apiURL = strings.TrimRight(cfg.BaseURL, "/") + "/chat/completions?api-version=2024-02-01"
+	o.authHeaderName = "api-key"
+	return &AzureOpenAI{OpenAI: o}, nil
+}
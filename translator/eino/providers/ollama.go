@@ -0,0 +1,180 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Ollama implements model.BaseChatModel against a local (or remote) Ollama
+// server's /api/chat endpoint.
+type Ollama struct {
+	cfg    Config
+	client *http.Client
+	apiURL string
+}
+
+// NewOllama builds an Ollama-backed chat model. BaseURL defaults to the
+// standard local Ollama port.
+func NewOllama(cfg Config, client *http.Client) *Ollama {
+	base := cfg.BaseURL
+	if base == "" {
+		base = "http://localhost:11434"
+	}
+	return &Ollama{cfg: cfg, client: client, apiURL: strings.TrimRight(base, "/") + "/api/chat"}
+}
+
+type ollamaMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Stream   bool            `json:"stream"`
+	Options  *ollamaOptions  `json:"options,omitempty"`
+}
+
+// ollamaOptions mirrors the subset of Ollama's nested "options" object that
+// model.Options maps onto; Ollama names max tokens "num_predict" rather than
+// "max_tokens".
+type ollamaOptions struct {
+	Temperature *float32 `json:"temperature,omitempty"`
+	NumPredict  *int     `json:"num_predict,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+}
+
+// toOllamaOptions translates resolved model.Options into Ollama's "options"
+// shape, or nil if none were set.
+func toOllamaOptions(opts []model.Option) *ollamaOptions {
+	resolved := resolveOptions(opts)
+	if resolved.Temperature == nil && resolved.MaxTokens == nil && len(resolved.Stop) == 0 {
+		return nil
+	}
+	return &ollamaOptions{Temperature: resolved.Temperature, NumPredict: resolved.MaxTokens, Stop: resolved.Stop}
+}
+
+type ollamaResponse struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	PromptEvalCount int           `json:"prompt_eval_count"`
+	EvalCount       int           `json:"eval_count"`
+}
+
+func toOllamaMessages(input []*schema.Message) []ollamaMessage {
+	out := make([]ollamaMessage, 0, len(input))
+	for _, m := range input {
+		out = append(out, ollamaMessage{Role: string(m.Role), Content: m.Content})
+	}
+	return out
+}
+
+func (o *Ollama) newRequest(ctx context.Context, body ollamaRequest) (*http.Request, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to marshal ollama request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to build ollama request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// Generate implements model.BaseChatModel.
+func (o *Ollama) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	req, err := o.newRequest(ctx, ollamaRequest{Model: o.cfg.Model, Messages: toOllamaMessages(input), Options: toOllamaOptions(opts)})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: ollama request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("providers: ollama returned status %d", resp.StatusCode)
+	}
+
+	var parsed ollamaResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("providers: failed to decode ollama response: %v", err)
+	}
+
+	out := &schema.Message{Role: schema.Assistant, Content: parsed.Message.Content}
+	setUsage(out, Usage{
+		PromptTokens:     parsed.PromptEvalCount,
+		CompletionTokens: parsed.EvalCount,
+		TotalTokens:      parsed.PromptEvalCount + parsed.EvalCount,
+	})
+	return out, nil
+}
+
+// Stream implements model.BaseChatModel against Ollama's newline-delimited
+// JSON stream (one ollamaResponse object per line, no SSE framing).
+func (o *Ollama) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	req, err := o.newRequest(ctx, ollamaRequest{Model: o.cfg.Model, Messages: toOllamaMessages(input), Stream: true, Options: toOllamaOptions(opts)})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: ollama stream request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("providers: ollama stream returned status %d", resp.StatusCode)
+	}
+
+	sr, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer resp.Body.Close()
+		defer sw.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			var chunk ollamaResponse
+			if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+				sw.Send(nil, fmt.Errorf("providers: failed to decode ollama stream chunk: %v", err))
+				return
+			}
+
+			msg := &schema.Message{Role: schema.Assistant, Content: chunk.Message.Content}
+			if chunk.Done {
+				setUsage(msg, Usage{
+					PromptTokens:     chunk.PromptEvalCount,
+					CompletionTokens: chunk.EvalCount,
+					TotalTokens:      chunk.PromptEvalCount + chunk.EvalCount,
+				})
+			}
+			if closed := sw.Send(msg, nil); closed {
+				return
+			}
+			if chunk.Done {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sw.Send(nil, fmt.Errorf("providers: ollama stream read failed: %v", err))
+		}
+	}()
+
+	return sr, nil
+}
@@ -0,0 +1,209 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// OpenAI implements model.BaseChatModel against the OpenAI Chat Completions
+// API (and anything that speaks the same wire format, e.g. Azure OpenAI via
+// AzureOpenAI below).
+type OpenAI struct {
+	cfg            Config
+	client         *http.Client
+	apiURL         string
+	authHeaderName string // overridden by AzureOpenAI, which uses "api-key" instead of "Authorization"
+}
+
+// NewOpenAI builds an OpenAI-backed chat model. BaseURL defaults to the
+// public OpenAI API if not overridden in cfg.
+func NewOpenAI(cfg Config, client *http.Client) *OpenAI {
+	base := cfg.BaseURL
+	if base == "" {
+		base = "https://api.openai.com/v1"
+	}
+	return &OpenAI{
+		cfg:            cfg,
+		client:         client,
+		apiURL:         strings.TrimRight(base, "/") + "/chat/completions",
+		authHeaderName: "Authorization",
+	}
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model       string          `json:"model"`
+	Messages    []openAIMessage `json:"messages"`
+	Stream      bool            `json:"stream"`
+	Temperature *float32        `json:"temperature,omitempty"`
+	MaxTokens   *int            `json:"max_tokens,omitempty"`
+	Stop        []string        `json:"stop,omitempty"`
+}
+
+// applyOptions copies the resolved model.Options onto an openAIRequest.
+func applyOptions(req *openAIRequest, opts []model.Option) {
+	resolved := resolveOptions(opts)
+	req.Temperature = resolved.Temperature
+	req.MaxTokens = resolved.MaxTokens
+	req.Stop = resolved.Stop
+}
+
+type openAIUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type openAIChoice struct {
+	Message openAIMessage `json:"message"`
+	Delta   openAIMessage `json:"delta"`
+}
+
+type openAIResponse struct {
+	Choices []openAIChoice `json:"choices"`
+	Usage   openAIUsage    `json:"usage"`
+}
+
+func toOpenAIMessages(input []*schema.Message) []openAIMessage {
+	out := make([]openAIMessage, 0, len(input))
+	for _, m := range input {
+		out = append(out, openAIMessage{Role: string(m.Role), Content: m.Content})
+	}
+	return out
+}
+
+func (o *OpenAI) newRequest(ctx context.Context, body openAIRequest) (*http.Request, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to marshal openai request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("providers: failed to build openai request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.authHeaderName == "api-key" {
+		req.Header.Set("api-key", o.cfg.APIKey)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+	}
+	return req, nil
+}
+
+// Generate implements model.BaseChatModel.
+func (o *OpenAI) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	body := openAIRequest{Model: o.cfg.Model, Messages: toOpenAIMessages(input)}
+	applyOptions(&body, opts)
+	req, err := o.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: openai request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("providers: openai returned status %d", resp.StatusCode)
+	}
+
+	var parsed openAIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("providers: failed to decode openai response: %v", err)
+	}
+	if len(parsed.Choices) == 0 {
+		return nil, fmt.Errorf("providers: openai response had no choices")
+	}
+
+	out := &schema.Message{Role: schema.Assistant, Content: parsed.Choices[0].Message.Content}
+	setUsage(out, Usage{
+		PromptTokens:     parsed.Usage.PromptTokens,
+		CompletionTokens: parsed.Usage.CompletionTokens,
+		TotalTokens:      parsed.Usage.TotalTokens,
+	})
+	return out, nil
+}
+
+// Stream implements model.BaseChatModel, translating OpenAI's
+// "data: {json}\n\n" SSE chunks into schema.Message deltas.
+func (o *OpenAI) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	body := openAIRequest{Model: o.cfg.Model, Messages: toOpenAIMessages(input), Stream: true}
+	applyOptions(&body, opts)
+	req, err := o.newRequest(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("providers: openai stream request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("providers: openai stream returned status %d", resp.StatusCode)
+	}
+
+	sr, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer resp.Body.Close()
+		defer sw.Close()
+
+		var usage Usage
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "[DONE]" {
+				return
+			}
+
+			var chunk openAIResponse
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				sw.Send(nil, fmt.Errorf("providers: failed to decode openai stream chunk: %v", err))
+				return
+			}
+			if chunk.Usage.TotalTokens > 0 {
+				usage = Usage(chunk.Usage)
+			}
+			if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+				if chunk.Usage.TotalTokens > 0 {
+					msg := &schema.Message{Role: schema.Assistant}
+					setUsage(msg, usage)
+					if closed := sw.Send(msg, nil); closed {
+						return
+					}
+				}
+				continue
+			}
+			msg := &schema.Message{Role: schema.Assistant, Content: chunk.Choices[0].Delta.Content}
+			if usage.TotalTokens > 0 {
+				setUsage(msg, usage)
+			}
+			if closed := sw.Send(msg, nil); closed {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			sw.Send(nil, fmt.Errorf("providers: openai stream read failed: %v", err))
+		}
+	}()
+
+	return sr, nil
+}
@@ -0,0 +1,92 @@
+// Package providers contains concrete model.BaseChatModel implementations
+// that talk to real LLM backends over HTTP, selectable at startup via
+// environment variables so the rest of the service can stay backend-agnostic.
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Config describes which backend to build and how to reach it. It is
+// populated from environment variables by ConfigFromEnv, but can also be
+// constructed directly for tests or non-env driven setups (e.g. the YAML
+// loader added later).
+type Config struct {
+	Provider string `yaml:"provider"` // "openai", "anthropic", "ollama", "azure-openai"
+	Model    string `yaml:"model"`
+	APIKey   string `yaml:"api_key"`
+	BaseURL  string `yaml:"base_url"` // optional override, e.g. for self-hosted or proxy endpoints
+}
+
+// ConfigFromEnv reads PROVIDER, MODEL, API_KEY and BASE_URL from the
+// environment. PROVIDER defaults to "openai" when unset.
+func ConfigFromEnv() Config {
+	provider := os.Getenv("PROVIDER")
+	if provider == "" {
+		provider = "openai"
+	}
+	return Config{
+		Provider: provider,
+		Model:    os.Getenv("MODEL"),
+		APIKey:   os.Getenv("API_KEY"),
+		BaseURL:  os.Getenv("BASE_URL"),
+	}
+}
+
+// New builds the model.BaseChatModel described by cfg.
+func New(cfg Config) (model.BaseChatModel, error) {
+	client := &http.Client{Timeout: 60 * time.Second}
+
+	switch cfg.Provider {
+	case "openai":
+		return NewOpenAI(cfg, client), nil
+	case "anthropic":
+		return NewAnthropic(cfg, client), nil
+	case "ollama":
+		return NewOllama(cfg, client), nil
+	case "azure-openai", "azure":
+		return NewAzureOpenAI(cfg, client)
+	default:
+		return nil, fmt.Errorf("providers: unknown provider %q", cfg.Provider)
+	}
+}
+
+// NewFromEnv is a convenience wrapper around ConfigFromEnv and New.
+func NewFromEnv() (model.BaseChatModel, error) {
+	return New(ConfigFromEnv())
+}
+
+// Usage carries per-request token accounting, normalized across providers
+// whose APIs name these fields differently (e.g. Anthropic's
+// input_tokens/output_tokens vs. OpenAI's prompt_tokens/completion_tokens).
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// usageExtraKey is where providers stash per-request Usage on the final
+// assistant message, so chatHandler can surface it in a closing SSE event.
+const usageExtraKey = "usage"
+
+// resolveOptions merges the model.Option values passed to Generate/Stream
+// (e.g. the defaults config.WrapWithDefaults applies, plus whatever the
+// caller passed explicitly) into a single model.Options, so each provider
+// can read the resolved Temperature/MaxTokens/Stop once instead of
+// re-implementing the fold itself.
+func resolveOptions(opts []model.Option) *model.Options {
+	return model.GetCommonOptions(&model.Options{}, opts...)
+}
+
+func setUsage(msg *schema.Message, u Usage) {
+	if msg.Extra == nil {
+		msg.Extra = map[string]any{}
+	}
+	msg.Extra[usageExtraKey] = u
+}
@@ -0,0 +1,144 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// roundTripFunc lets a test stub http.Client.Do without touching the
+// network, so Generate/Stream can be tested against canned responses
+// (including ones that fail mid-body, for the scanner.Err() tests below).
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func clientReturning(body string, status int) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})}
+}
+
+// brokenBodyReader yields some valid lines and then a read error, simulating
+// a dropped connection mid-stream.
+type brokenBodyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (b *brokenBodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	if err == io.EOF {
+		return n, b.err
+	}
+	return n, err
+}
+
+func clientWithBrokenStream(goodLines string, readErr error) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(&brokenBodyReader{r: strings.NewReader(goodLines), err: readErr}),
+			Header:     make(http.Header),
+		}, nil
+	})}
+}
+
+func drainStream(t *testing.T, sr *schema.StreamReader[*schema.Message]) ([]*schema.Message, error) {
+	t.Helper()
+	var msgs []*schema.Message
+	for {
+		msg, err := sr.Recv()
+		if err == io.EOF {
+			return msgs, nil
+		}
+		if err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestOpenAIGenerateNonOKStatus(t *testing.T) {
+	o := NewOpenAI(Config{Model: "gpt-4"}, clientReturning(`{}`, http.StatusInternalServerError))
+	if _, err := o.Generate(context.Background(), nil); err == nil {
+		t.Error("Generate with a non-200 response = nil error, want an error")
+	}
+}
+
+func TestOpenAIStreamSurfacesReadError(t *testing.T) {
+	goodLines := "data: {\"choices\":[{\"delta\":{\"content\":\"hi\"}}]}\n\n"
+	o := NewOpenAI(Config{Model: "gpt-4"}, clientWithBrokenStream(goodLines, errors.New("connection reset by peer")))
+
+	sr, err := o.Stream(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	msgs, streamErr := drainStream(t, sr)
+	if len(msgs) != 1 || msgs[0].Content != "hi" {
+		t.Fatalf("got messages %+v, want one message with content %q", msgs, "hi")
+	}
+	if streamErr == nil {
+		t.Fatal("drainStream returned nil error after a dropped connection, want the scanner's read error surfaced")
+	}
+	if !strings.Contains(streamErr.Error(), "connection reset by peer") {
+		t.Errorf("error %v doesn't mention the underlying read failure", streamErr)
+	}
+}
+
+func TestAnthropicGenerateNonOKStatus(t *testing.T) {
+	a := NewAnthropic(Config{Model: "claude-3"}, clientReturning(`{}`, http.StatusTooManyRequests))
+	if _, err := a.Generate(context.Background(), nil); err == nil {
+		t.Error("Generate with a non-200 response = nil error, want an error")
+	}
+}
+
+func TestAnthropicStreamSurfacesReadError(t *testing.T) {
+	goodLines := "data: {\"type\":\"content_block_delta\",\"delta\":{\"type\":\"text_delta\",\"text\":\"hi\"}}\n\n"
+	a := NewAnthropic(Config{Model: "claude-3"}, clientWithBrokenStream(goodLines, errors.New("connection reset by peer")))
+
+	sr, err := a.Stream(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	msgs, streamErr := drainStream(t, sr)
+	if len(msgs) != 1 || msgs[0].Content != "hi" {
+		t.Fatalf("got messages %+v, want one message with content %q", msgs, "hi")
+	}
+	if streamErr == nil {
+		t.Fatal("drainStream returned nil error after a dropped connection, want the scanner's read error surfaced")
+	}
+}
+
+func TestOllamaGenerateNonOKStatus(t *testing.T) {
+	o := NewOllama(Config{Model: "llama3"}, clientReturning(`{}`, http.StatusServiceUnavailable))
+	if _, err := o.Generate(context.Background(), nil); err == nil {
+		t.Error("Generate with a non-200 response = nil error, want an error")
+	}
+}
+
+func TestOllamaStreamSurfacesReadError(t *testing.T) {
+	goodLines := "{\"message\":{\"role\":\"assistant\",\"content\":\"hi\"},\"done\":false}\n"
+	o := NewOllama(Config{Model: "llama3"}, clientWithBrokenStream(goodLines, errors.New("connection reset by peer")))
+
+	sr, err := o.Stream(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	msgs, streamErr := drainStream(t, sr)
+	if len(msgs) != 1 || msgs[0].Content != "hi" {
+		t.Fatalf("got messages %+v, want one message with content %q", msgs, "hi")
+	}
+	if streamErr == nil {
+		t.Fatal("drainStream returned nil error after a dropped connection, want the scanner's read error surfaced")
+	}
+}
@@ -0,0 +1,86 @@
+package rag
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// ExtractText converts raw ingested bytes to plain text based on
+// contentType. "text/plain" and "text/markdown" (and no content type at
+// all) pass through unchanged — markdown's structure is useful to an LLM
+// as-is; "application/pdf" extracts the document's embedded text layer.
+func ExtractText(contentType string, data []byte) (string, error) {
+	switch contentType {
+	case "", "text/plain", "text/markdown":
+		return string(data), nil
+	case "application/pdf":
+		return extractPDFText(data)
+	default:
+		return "", fmt.Errorf("rag: unsupported content type %q", contentType)
+	}
+}
+
+func extractPDFText(data []byte) (string, error) {
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("rag: failed to open PDF: %v", err)
+	}
+
+	var text strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		content, err := page.GetPlainText(nil)
+		if err != nil {
+			return "", fmt.Errorf("rag: failed to extract text from page %d: %v", i, err)
+		}
+		text.WriteString(content)
+	}
+	return text.String(), nil
+}
+
+// Chunk splits text into overlapping chunks of approximately chunkTokens
+// tokens each, with overlapTokens of the previous chunk repeated at the
+// start of the next so retrieval doesn't lose context at chunk boundaries.
+// Token counts are approximated at ~0.75 words per token, the same rule of
+// thumb session.EstimateTokens uses on the character side.
+func Chunk(text string, chunkTokens, overlapTokens int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if chunkTokens <= 0 {
+		chunkTokens = 200
+	}
+	if overlapTokens < 0 || overlapTokens >= chunkTokens {
+		overlapTokens = 0
+	}
+
+	wordsPerChunk := chunkTokens * 3 / 4
+	if wordsPerChunk < 1 {
+		wordsPerChunk = 1
+	}
+	wordsOverlap := overlapTokens * 3 / 4
+	step := wordsPerChunk - wordsOverlap
+	if step < 1 {
+		step = 1
+	}
+
+	var chunks []string
+	for start := 0; start < len(words); start += step {
+		end := start + wordsPerChunk
+		if end > len(words) {
+			end = len(words)
+		}
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return chunks
+}
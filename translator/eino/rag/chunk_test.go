@@ -0,0 +1,95 @@
+package rag
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChunkEmpty(t *testing.T) {
+	if got := Chunk("", 100, 20); got != nil {
+		t.Errorf("Chunk(\"\") = %v, want nil", got)
+	}
+	if got := Chunk("   \n\t  ", 100, 20); got != nil {
+		t.Errorf("Chunk(whitespace) = %v, want nil", got)
+	}
+}
+
+func TestChunkSingleChunk(t *testing.T) {
+	text := strings.Repeat("word ", 10)
+	chunks := Chunk(text, 100, 20)
+	if len(chunks) != 1 {
+		t.Fatalf("got %d chunks, want 1: %v", len(chunks), chunks)
+	}
+	if chunks[0] != strings.TrimSpace(text) {
+		t.Errorf("chunk = %q, want %q", chunks[0], strings.TrimSpace(text))
+	}
+}
+
+func TestChunkSplitsOnWordCount(t *testing.T) {
+	words := make([]string, 100)
+	for i := range words {
+		words[i] = "w"
+	}
+	text := strings.Join(words, " ")
+
+	// chunkTokens=40 -> 30 words/chunk; overlapTokens=0 -> step=30.
+	chunks := Chunk(text, 40, 0)
+	if len(chunks) != 4 {
+		t.Fatalf("got %d chunks, want 4: %v", len(chunks), chunks)
+	}
+	for i, want := range []int{30, 30, 30, 10} {
+		got := len(strings.Fields(chunks[i]))
+		if got != want {
+			t.Errorf("chunk %d has %d words, want %d", i, got, want)
+		}
+	}
+}
+
+func TestChunkOverlapRepeatsWords(t *testing.T) {
+	words := make([]string, 20)
+	for i := range words {
+		words[i] = strings.Repeat("x", i+1) // every word unique
+	}
+	text := strings.Join(words, " ")
+
+	// chunkTokens=16 -> 12 words/chunk; overlapTokens=8 -> 6 words overlap; step=6.
+	chunks := Chunk(text, 16, 8)
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want at least 2: %v", len(chunks), chunks)
+	}
+	firstTail := strings.Fields(chunks[0])[6:]
+	secondHead := strings.Fields(chunks[1])[:6]
+	if strings.Join(firstTail, " ") != strings.Join(secondHead, " ") {
+		t.Errorf("overlap mismatch: chunk0 tail %v, chunk1 head %v", firstTail, secondHead)
+	}
+}
+
+func TestChunkInvalidOverlapFallsBackToZero(t *testing.T) {
+	words := make([]string, 30)
+	for i := range words {
+		words[i] = "w"
+	}
+	text := strings.Join(words, " ")
+
+	withBadOverlap := Chunk(text, 40, 40) // overlap == chunkTokens
+	withNoOverlap := Chunk(text, 40, 0)
+	if len(withBadOverlap) != len(withNoOverlap) {
+		t.Errorf("overlap >= chunkTokens should behave like overlap=0: got %d chunks, want %d", len(withBadOverlap), len(withNoOverlap))
+	}
+}
+
+func TestExtractTextUnsupportedContentType(t *testing.T) {
+	if _, err := ExtractText("application/zip", []byte("x")); err == nil {
+		t.Error("expected an error for an unsupported content type")
+	}
+}
+
+func TestExtractTextPlain(t *testing.T) {
+	got, err := ExtractText("text/plain", []byte("hello"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "hello" {
+		t.Errorf("got %q, want %q", got, "hello")
+	}
+}
@@ -0,0 +1,29 @@
+package rag
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewStoreFromEnv builds the VectorStore selected by RAG_VECTOR_STORE:
+// "memory" (the default), "pgvector", or "qdrant".
+func NewStoreFromEnv() (VectorStore, error) {
+	switch backend := os.Getenv("RAG_VECTOR_STORE"); backend {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "pgvector":
+		dsn := os.Getenv("RAG_PGVECTOR_DSN")
+		if dsn == "" {
+			return nil, fmt.Errorf("rag: RAG_PGVECTOR_DSN is required when RAG_VECTOR_STORE=pgvector")
+		}
+		return NewPGVectorStore(dsn)
+	case "qdrant":
+		baseURL := os.Getenv("RAG_QDRANT_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:6333"
+		}
+		return NewQdrantStore(baseURL, os.Getenv("RAG_QDRANT_API_KEY")), nil
+	default:
+		return nil, fmt.Errorf("rag: unknown RAG_VECTOR_STORE %q", backend)
+	}
+}
@@ -0,0 +1,68 @@
+// Package rag adds retrieval-augmented generation to the chat service: an
+// Eino graph node that embeds the latest user message, looks up the
+// closest matching documents in a pluggable VectorStore, and injects them
+// into the model's system prompt, plus the ingestion pipeline that gets
+// documents into that store in the first place.
+package rag
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"regexp"
+)
+
+// Document is one chunk of ingested content, embedded and stored for
+// retrieval.
+type Document struct {
+	// ID is a UUID, unique within its collection. It's generated rather
+	// than derived from DocID because QdrantStore requires point IDs to be
+	// an unsigned integer or a UUID; the caller-supplied DocID (and the
+	// chunk's position within it) is kept in Metadata instead for anything
+	// that wants a human-readable reference.
+	ID       string            `json:"id"`
+	DocID    string            `json:"doc_id"` // the originally ingested document's id; several chunks share one
+	Content  string            `json:"content"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Vector   []float64         `json:"-"`
+}
+
+// newDocumentID generates a random UUIDv4 for Document.ID.
+func newDocumentID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("rag: failed to generate document id: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ScoredDocument is a Document returned from a similarity Query, along with
+// how closely it matched (cosine similarity; higher is closer).
+type ScoredDocument struct {
+	Document
+	Score float64 `json:"score"`
+}
+
+// VectorStore persists embedded Documents per collection and serves
+// nearest-neighbor lookups against them.
+type VectorStore interface {
+	// Upsert stores or replaces docs in collection.
+	Upsert(ctx context.Context, collection string, docs []Document) error
+	// DeleteDocument removes every chunk belonging to docID from collection.
+	DeleteDocument(ctx context.Context, collection, docID string) error
+	// Query returns the topK documents in collection closest to vector.
+	Query(ctx context.Context, collection string, vector []float64, topK int) ([]ScoredDocument, error)
+}
+
+var collectionNameRE = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// validateCollectionName guards backends (PGVectorStore) that interpolate
+// collection into identifiers they can't otherwise parameterize.
+func validateCollectionName(collection string) error {
+	if !collectionNameRE.MatchString(collection) {
+		return fmt.Errorf("rag: invalid collection name %q (must match %s)", collection, collectionNameRE.String())
+	}
+	return nil
+}
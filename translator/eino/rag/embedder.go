@@ -0,0 +1,110 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Embedder turns text into vectors for similarity search, playing the same
+// role for retrieval that model.BaseChatModel plays for generation.
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// OpenAIEmbedder implements Embedder against the OpenAI Embeddings API.
+type OpenAIEmbedder struct {
+	model  string
+	apiKey string
+	apiURL string
+	client *http.Client
+}
+
+// NewOpenAIEmbedder builds an OpenAI-backed Embedder. baseURL defaults to
+// the public OpenAI API if empty.
+func NewOpenAIEmbedder(modelName, apiKey, baseURL string) *OpenAIEmbedder {
+	if baseURL == "" {
+		baseURL = "https://api.openai.com/v1"
+	}
+	return &OpenAIEmbedder{
+		model:  modelName,
+		apiKey: apiKey,
+		apiURL: strings.TrimRight(baseURL, "/") + "/embeddings",
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// Embed implements Embedder.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	payload, err := json.Marshal(openAIEmbeddingRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to marshal embeddings request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.apiURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to build embeddings request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+e.apiKey)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rag: embeddings request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rag: embeddings endpoint returned status %d", resp.StatusCode)
+	}
+
+	var parsed openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("rag: failed to decode embeddings response: %v", err)
+	}
+
+	out := make([][]float64, len(parsed.Data))
+	for i, d := range parsed.Data {
+		out[i] = d.Embedding
+	}
+	return out, nil
+}
+
+// NewEmbedderFromEnv builds the Embedder selected by EMBEDDING_PROVIDER
+// (only "openai" is supported today). EMBEDDING_MODEL and
+// EMBEDDING_BASE_URL configure it; EMBEDDING_API_KEY is used if set,
+// falling back to API_KEY so a single provider credential can cover both
+// chat and embeddings.
+func NewEmbedderFromEnv() (Embedder, error) {
+	provider := os.Getenv("EMBEDDING_PROVIDER")
+	if provider == "" {
+		provider = "openai"
+	}
+	apiKey := os.Getenv("EMBEDDING_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("API_KEY")
+	}
+
+	switch provider {
+	case "openai":
+		return NewOpenAIEmbedder(os.Getenv("EMBEDDING_MODEL"), apiKey, os.Getenv("EMBEDDING_BASE_URL")), nil
+	default:
+		return nil, fmt.Errorf("rag: unknown EMBEDDING_PROVIDER %q", provider)
+	}
+}
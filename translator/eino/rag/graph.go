@@ -0,0 +1,122 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Retriever embeds a query and looks up the closest matching documents for
+// Collection in Store.
+type Retriever struct {
+	Store      VectorStore
+	Embedder   Embedder
+	Collection string
+	TopK       int
+}
+
+// Retrieve embeds query and returns the TopK closest documents in
+// r.Collection.
+func (r *Retriever) Retrieve(ctx context.Context, query string) ([]ScoredDocument, error) {
+	vectors, err := r.Embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to embed query: %v", err)
+	}
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("rag: embedder returned no vectors for the query")
+	}
+	return r.Store.Query(ctx, r.Collection, vectors[0], r.TopK)
+}
+
+// RenderSystemPrompt fills {{.Context}} in systemTemplate with the
+// retrieved documents' content, joined with blank lines, mirroring
+// config.Model.RenderSystemPrompt's use of text/template for the
+// non-retrieval system prompt.
+func RenderSystemPrompt(systemTemplate string, docs []ScoredDocument) (string, error) {
+	contents := make([]string, len(docs))
+	for i, d := range docs {
+		contents[i] = d.Content
+	}
+
+	tmpl, err := template.New("rag:system").Parse(systemTemplate)
+	if err != nil {
+		return "", fmt.Errorf("rag: invalid system template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Context string }{Context: strings.Join(contents, "\n\n")}); err != nil {
+		return "", fmt.Errorf("rag: failed to render system template: %v", err)
+	}
+	return buf.String(), nil
+}
+
+// retrievalStep is the Eino graph lambda that performs retrieval and
+// prepends the rendered system message to the conversation. It uses the
+// latest schema.User message as the query; if there is none, the messages
+// are passed through unmodified.
+func retrievalStep(r *Retriever, systemTemplate string) func(ctx context.Context, messages []*schema.Message) ([]*schema.Message, error) {
+	return func(ctx context.Context, messages []*schema.Message) ([]*schema.Message, error) {
+		query := lastUserContent(messages)
+		if query == "" {
+			return messages, nil
+		}
+
+		docs, err := r.Retrieve(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		system, err := RenderSystemPrompt(systemTemplate, docs)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]*schema.Message, 0, len(messages)+1)
+		out = append(out, &schema.Message{Role: schema.System, Content: system})
+		out = append(out, messages...)
+		return out, nil
+	}
+}
+
+func lastUserContent(messages []*schema.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == schema.User {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// BuildChain compiles an Eino chain that runs retrieval before chatModel,
+// so the model sees documents retrieved for the latest user message
+// injected into its system prompt via systemTemplate's {{.Context}}
+// placeholder.
+func BuildChain(ctx context.Context, chatModel model.BaseChatModel, r *Retriever, systemTemplate string) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	graph := compose.NewGraph[[]*schema.Message, *schema.Message]()
+	if err := graph.AddLambdaNode("retrieve", compose.InvokableLambda(retrievalStep(r, systemTemplate))); err != nil {
+		return nil, fmt.Errorf("rag: failed to add retrieve node: %v", err)
+	}
+	if err := graph.AddChatModelNode("model", chatModel); err != nil {
+		return nil, fmt.Errorf("rag: failed to add model node: %v", err)
+	}
+	if err := graph.AddEdge(compose.START, "retrieve"); err != nil {
+		return nil, fmt.Errorf("rag: failed to wire start edge: %v", err)
+	}
+	if err := graph.AddEdge("retrieve", "model"); err != nil {
+		return nil, fmt.Errorf("rag: failed to wire retrieve edge: %v", err)
+	}
+	if err := graph.AddEdge("model", compose.END); err != nil {
+		return nil, fmt.Errorf("rag: failed to wire end edge: %v", err)
+	}
+
+	chain, err := graph.Compile(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to compile retrieval chain: %v", err)
+	}
+	return chain, nil
+}
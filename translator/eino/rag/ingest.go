@@ -0,0 +1,61 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+)
+
+// Ingestor chunks and embeds a document before storing it, tying together
+// an Embedder, a VectorStore, and the chunking parameters into the single
+// operation the ingestion API needs.
+type Ingestor struct {
+	Store         VectorStore
+	Embedder      Embedder
+	ChunkTokens   int
+	OverlapTokens int
+}
+
+// Ingest extracts text from data (per contentType), splits it into
+// overlapping chunks, embeds each one, and upserts them into collection as
+// Documents sharing docID. Any chunks already stored for docID are deleted
+// first, so re-ingesting a document that now produces fewer chunks doesn't
+// leave the tail of the old version behind as stale, retrievable orphans.
+// It returns the number of chunks stored.
+func (ing *Ingestor) Ingest(ctx context.Context, collection, docID, contentType string, data []byte) (int, error) {
+	text, err := ExtractText(contentType, data)
+	if err != nil {
+		return 0, err
+	}
+
+	chunks := Chunk(text, ing.ChunkTokens, ing.OverlapTokens)
+	if len(chunks) == 0 {
+		return 0, fmt.Errorf("rag: document %q contained no text to ingest", docID)
+	}
+
+	vectors, err := ing.Embedder.Embed(ctx, chunks)
+	if err != nil {
+		return 0, fmt.Errorf("rag: failed to embed document %q: %v", docID, err)
+	}
+	if len(vectors) != len(chunks) {
+		return 0, fmt.Errorf("rag: embedder returned %d vectors for %d chunks", len(vectors), len(chunks))
+	}
+
+	docs := make([]Document, len(chunks))
+	for i, chunk := range chunks {
+		docs[i] = Document{
+			ID:       newDocumentID(),
+			DocID:    docID,
+			Content:  chunk,
+			Vector:   vectors[i],
+			Metadata: map[string]string{"chunk_index": fmt.Sprintf("%d", i)},
+		}
+	}
+
+	if err := ing.Store.DeleteDocument(ctx, collection, docID); err != nil {
+		return 0, fmt.Errorf("rag: failed to clear previous chunks for %q: %v", docID, err)
+	}
+	if err := ing.Store.Upsert(ctx, collection, docs); err != nil {
+		return 0, fmt.Errorf("rag: failed to store document %q: %v", docID, err)
+	}
+	return len(docs), nil
+}
@@ -0,0 +1,89 @@
+package rag
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+// MemoryStore is an in-process VectorStore using brute-force cosine
+// similarity; fine for small collections or local development, but doesn't
+// scale or persist history across restarts.
+type MemoryStore struct {
+	mu          sync.Mutex
+	collections map[string][]Document
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{collections: make(map[string][]Document)}
+}
+
+func (s *MemoryStore) Upsert(_ context.Context, collection string, docs []Document) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := s.collections[collection]
+	byID := make(map[string]int, len(existing))
+	for i, d := range existing {
+		byID[d.ID] = i
+	}
+	for _, d := range docs {
+		if i, ok := byID[d.ID]; ok {
+			existing[i] = d
+			continue
+		}
+		byID[d.ID] = len(existing)
+		existing = append(existing, d)
+	}
+	s.collections[collection] = existing
+	return nil
+}
+
+func (s *MemoryStore) DeleteDocument(_ context.Context, collection, docID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing := s.collections[collection]
+	kept := existing[:0]
+	for _, d := range existing {
+		if d.DocID != docID {
+			kept = append(kept, d)
+		}
+	}
+	s.collections[collection] = kept
+	return nil
+}
+
+func (s *MemoryStore) Query(_ context.Context, collection string, vector []float64, topK int) ([]ScoredDocument, error) {
+	s.mu.Lock()
+	docs := append([]Document(nil), s.collections[collection]...)
+	s.mu.Unlock()
+
+	scored := make([]ScoredDocument, 0, len(docs))
+	for _, d := range docs {
+		scored = append(scored, ScoredDocument{Document: d, Score: cosineSimilarity(vector, d.Vector)})
+	}
+	sort.Slice(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	if topK > 0 && len(scored) > topK {
+		scored = scored[:topK]
+	}
+	return scored, nil
+}
+
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
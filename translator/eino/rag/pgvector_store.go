@@ -0,0 +1,131 @@
+package rag
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PGVectorStore persists Documents in Postgres using the pgvector
+// extension for similarity search. Each collection gets its own table
+// (rag_<collection>), since distinct collections can carry
+// differently-sized embedding vectors.
+type PGVectorStore struct {
+	db *sql.DB
+}
+
+// NewPGVectorStore opens a connection pool against the Postgres instance
+// at dsn. The pgvector extension must already be installed on that
+// database (CREATE EXTENSION vector).
+func NewPGVectorStore(dsn string) (*PGVectorStore, error) {
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to open postgres connection: %v", err)
+	}
+	return &PGVectorStore{db: db}, nil
+}
+
+func tableName(collection string) string {
+	return "rag_" + collection
+}
+
+func (s *PGVectorStore) ensureTable(ctx context.Context, collection string, dims int) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		id TEXT PRIMARY KEY,
+		doc_id TEXT NOT NULL,
+		content TEXT NOT NULL,
+		metadata JSONB,
+		embedding VECTOR(%d)
+	)`, tableName(collection), dims))
+	if err != nil {
+		return fmt.Errorf("rag: failed to create table for collection %q: %v", collection, err)
+	}
+	return nil
+}
+
+func vectorLiteral(v []float64) string {
+	parts := make([]string, len(v))
+	for i, f := range v {
+		parts[i] = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+func (s *PGVectorStore) Upsert(ctx context.Context, collection string, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := validateCollectionName(collection); err != nil {
+		return err
+	}
+	if err := s.ensureTable(ctx, collection, len(docs[0].Vector)); err != nil {
+		return err
+	}
+
+	for _, d := range docs {
+		metadata, err := json.Marshal(d.Metadata)
+		if err != nil {
+			return fmt.Errorf("rag: failed to encode metadata for %q: %v", d.ID, err)
+		}
+		_, err = s.db.ExecContext(ctx, fmt.Sprintf(`
+			INSERT INTO %s (id, doc_id, content, metadata, embedding) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET doc_id = excluded.doc_id, content = excluded.content, metadata = excluded.metadata, embedding = excluded.embedding`,
+			tableName(collection)),
+			d.ID, d.DocID, d.Content, string(metadata), vectorLiteral(d.Vector))
+		if err != nil {
+			return fmt.Errorf("rag: failed to upsert %q: %v", d.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *PGVectorStore) DeleteDocument(ctx context.Context, collection, docID string) error {
+	if err := validateCollectionName(collection); err != nil {
+		return err
+	}
+	if _, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE doc_id = $1`, tableName(collection)), docID); err != nil {
+		return fmt.Errorf("rag: failed to delete document %q: %v", docID, err)
+	}
+	return nil
+}
+
+func (s *PGVectorStore) Query(ctx context.Context, collection string, vector []float64, topK int) ([]ScoredDocument, error) {
+	if err := validateCollectionName(collection); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, doc_id, content, metadata, 1 - (embedding <=> $1) AS score
+		FROM %s ORDER BY embedding <=> $1 LIMIT $2`, tableName(collection)),
+		vectorLiteral(vector), topK)
+	if err != nil {
+		return nil, fmt.Errorf("rag: query failed: %v", err)
+	}
+	defer rows.Close()
+
+	var out []ScoredDocument
+	for rows.Next() {
+		var d ScoredDocument
+		var metadata string
+		if err := rows.Scan(&d.ID, &d.DocID, &d.Content, &metadata, &d.Score); err != nil {
+			return nil, fmt.Errorf("rag: failed to scan result: %v", err)
+		}
+		if metadata != "" {
+			if err := json.Unmarshal([]byte(metadata), &d.Metadata); err != nil {
+				return nil, fmt.Errorf("rag: failed to decode metadata: %v", err)
+			}
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// Close releases the underlying connection pool.
+func (s *PGVectorStore) Close() error {
+	return s.db.Close()
+}
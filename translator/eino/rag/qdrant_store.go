@@ -0,0 +1,161 @@
+package rag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// QdrantStore persists Documents in a Qdrant collection via its REST API.
+type QdrantStore struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewQdrantStore builds a QdrantStore against the Qdrant instance at
+// baseURL (e.g. "http://localhost:6333"). apiKey may be empty for
+// unauthenticated instances.
+func NewQdrantStore(baseURL, apiKey string) *QdrantStore {
+	return &QdrantStore{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *QdrantStore) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("rag: failed to marshal qdrant request: %v", err)
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("rag: failed to build qdrant request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("api-key", s.apiKey)
+	}
+	return req, nil
+}
+
+func (s *QdrantStore) do(req *http.Request, out interface{}) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("rag: qdrant request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("rag: qdrant returned status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type qdrantPoint struct {
+	ID      string                 `json:"id"`
+	Vector  []float64              `json:"vector"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+func toQdrantPayload(d Document) map[string]interface{} {
+	payload := map[string]interface{}{"doc_id": d.DocID, "content": d.Content}
+	for k, v := range d.Metadata {
+		payload[k] = v
+	}
+	return payload
+}
+
+// ensureCollection creates collection with the given vector dims if it
+// doesn't already exist. Qdrant's collection-create endpoint is itself
+// idempotent (re-creating with the same config is a no-op), so this is
+// safe to call on every Upsert rather than tracking which collections have
+// already been created.
+func (s *QdrantStore) ensureCollection(ctx context.Context, collection string, dims int) error {
+	req, err := s.newRequest(ctx, http.MethodPut, "/collections/"+url.PathEscape(collection),
+		map[string]interface{}{"vectors": map[string]interface{}{"size": dims, "distance": "Cosine"}})
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+func (s *QdrantStore) Upsert(ctx context.Context, collection string, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	if err := s.ensureCollection(ctx, collection, len(docs[0].Vector)); err != nil {
+		return fmt.Errorf("rag: failed to ensure qdrant collection %q: %v", collection, err)
+	}
+
+	points := make([]qdrantPoint, len(docs))
+	for i, d := range docs {
+		points[i] = qdrantPoint{ID: d.ID, Vector: d.Vector, Payload: toQdrantPayload(d)}
+	}
+	req, err := s.newRequest(ctx, http.MethodPut, "/collections/"+url.PathEscape(collection)+"/points", map[string]interface{}{"points": points})
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+func (s *QdrantStore) DeleteDocument(ctx context.Context, collection, docID string) error {
+	filter := map[string]interface{}{
+		"filter": map[string]interface{}{
+			"must": []map[string]interface{}{
+				{"key": "doc_id", "match": map[string]interface{}{"value": docID}},
+			},
+		},
+	}
+	req, err := s.newRequest(ctx, http.MethodPost, "/collections/"+url.PathEscape(collection)+"/points/delete", filter)
+	if err != nil {
+		return err
+	}
+	return s.do(req, nil)
+}
+
+type qdrantSearchResult struct {
+	Result []struct {
+		ID      string                 `json:"id"`
+		Score   float64                `json:"score"`
+		Payload map[string]interface{} `json:"payload"`
+	} `json:"result"`
+}
+
+func (s *QdrantStore) Query(ctx context.Context, collection string, vector []float64, topK int) ([]ScoredDocument, error) {
+	req, err := s.newRequest(ctx, http.MethodPost, "/collections/"+url.PathEscape(collection)+"/points/search",
+		map[string]interface{}{"vector": vector, "limit": topK, "with_payload": true})
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed qdrantSearchResult
+	if err := s.do(req, &parsed); err != nil {
+		return nil, err
+	}
+
+	out := make([]ScoredDocument, len(parsed.Result))
+	for i, r := range parsed.Result {
+		doc := Document{ID: r.ID}
+		if docID, ok := r.Payload["doc_id"].(string); ok {
+			doc.DocID = docID
+		}
+		if content, ok := r.Payload["content"].(string); ok {
+			doc.Content = content
+		}
+		out[i] = ScoredDocument{Document: doc, Score: r.Score}
+	}
+	return out, nil
+}
@@ -0,0 +1,25 @@
+package router
+
+import "strings"
+
+// isHealthAffecting reports whether err looks like it came from the
+// backend itself (auth failure, rate limit, timeout, 5xx) rather than from
+// the caller (bad request, canceled context). Only health-affecting errors
+// count towards ejecting a backend.
+func isHealthAffecting(err error) bool {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "status 401"), strings.Contains(msg, "status 403"):
+		return true // auth failure
+	case strings.Contains(msg, "status 429"):
+		return true // rate limited
+	case strings.Contains(msg, "status 5"):
+		return true // upstream 5xx
+	case strings.Contains(msg, "timeout"), strings.Contains(msg, "deadline exceeded"):
+		return true
+	case strings.Contains(msg, "connection refused"), strings.Contains(msg, "no such host"):
+		return true
+	default:
+		return false
+	}
+}
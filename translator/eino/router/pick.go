@@ -0,0 +1,128 @@
+package router
+
+import "fmt"
+
+// pick selects the backend to dispatch to. A non-empty override always
+// wins, as long as it names a known, currently healthy backend; otherwise
+// it falls through to the configured strategy among healthy backends.
+func (r *Router) pick(override string) (*backend, error) {
+	if override != "" {
+		b, ok := r.byName[override]
+		if !ok {
+			return nil, fmt.Errorf("router: unknown model %q", override)
+		}
+		b.mu.Lock()
+		healthy := b.isHealthyLocked()
+		b.mu.Unlock()
+		if !healthy {
+			return nil, fmt.Errorf("router: requested model %q is currently unhealthy", override)
+		}
+		return b, nil
+	}
+
+	healthy := r.healthyBackends()
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("router: no healthy backends available")
+	}
+
+	switch r.strategy {
+	case StrategyRoundRobin:
+		return r.pickRoundRobin(healthy), nil
+	case StrategyLeastLatency:
+		return pickLeastLatency(healthy), nil
+	case StrategyWeighted:
+		return r.pickWeighted(healthy), nil
+	case StrategyPriority:
+		fallthrough
+	default:
+		return pickPriority(healthy), nil
+	}
+}
+
+func (r *Router) healthyBackends() []*backend {
+	out := make([]*backend, 0, len(r.backends))
+	for _, b := range r.backends {
+		b.mu.Lock()
+		ok := b.isHealthyLocked()
+		b.mu.Unlock()
+		if ok {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// pickPriority returns the lowest-Priority backend (ties broken by
+// declaration order).
+func pickPriority(healthy []*backend) *backend {
+	best := healthy[0]
+	for _, b := range healthy[1:] {
+		if b.spec.Priority < best.spec.Priority {
+			best = b
+		}
+	}
+	return best
+}
+
+func (r *Router) pickRoundRobin(healthy []*backend) *backend {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := healthy[r.rrNext%len(healthy)]
+	r.rrNext++
+	return b
+}
+
+func pickLeastLatency(healthy []*backend) *backend {
+	best := healthy[0]
+	bestLatency := best.snapshotLatency()
+	for _, b := range healthy[1:] {
+		if l := b.snapshotLatency(); l < bestLatency {
+			best, bestLatency = b, l
+		}
+	}
+	return best
+}
+
+func (b *backend) snapshotLatency() (d int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// A backend with no observed latency yet (never dispatched to) is
+	// treated as the fastest, so it gets a chance to prove itself.
+	return b.lastLatency.Nanoseconds()
+}
+
+// pickWeighted does a weighted pick over healthy backends using
+// their configured Weight (defaulting to 1 when unset).
+func (r *Router) pickWeighted(healthy []*backend) *backend {
+	total := 0
+	for _, b := range healthy {
+		total += weightOf(b)
+	}
+	if total <= 0 {
+		return healthy[0]
+	}
+
+	r.mu.Lock()
+	// Deterministic round-robin-over-weight rather than math/rand: it
+	// distributes proportionally to Weight without needing a PRNG seed,
+	// and keeps selection reproducible in tests.
+	target := r.rrNext % total
+	r.rrNext++
+	r.mu.Unlock()
+
+	for _, b := range healthy {
+		w := weightOf(b)
+		if target < w {
+			return b
+		}
+		target -= w
+	}
+	return healthy[len(healthy)-1]
+}
+
+func weightOf(b *backend) int {
+	if b.spec.Weight <= 0 {
+		return 1
+	}
+	return b.spec.Weight
+}
@@ -0,0 +1,138 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/luomsis/agent4everything/translator/eino/providers"
+)
+
+// stubBuild is a ChainBuilder that never gets invoked by these tests, since
+// pick (unlike Chain) doesn't compile a chain.
+func stubBuild(_ context.Context, _ model.BaseChatModel) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	return nil, nil
+}
+
+func newTestRouter(t *testing.T, strategy Strategy, specs []BackendSpec) *Router {
+	t.Helper()
+	r, err := New(strategy, specs, stubBuild)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return r
+}
+
+func specs(names ...string) []BackendSpec {
+	out := make([]BackendSpec, len(names))
+	for i, name := range names {
+		out[i] = BackendSpec{Name: name, Config: providers.Config{Provider: "openai"}}
+	}
+	return out
+}
+
+func TestPickPriorityPrefersLowestPriority(t *testing.T) {
+	r := newTestRouter(t, StrategyPriority, []BackendSpec{
+		{Name: "b", Config: providers.Config{Provider: "openai"}, Priority: 2},
+		{Name: "a", Config: providers.Config{Provider: "openai"}, Priority: 1},
+	})
+
+	b, err := r.pick("")
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	if b.spec.Name != "a" {
+		t.Errorf("picked %q, want %q", b.spec.Name, "a")
+	}
+}
+
+func TestPickOverrideUnknownName(t *testing.T) {
+	r := newTestRouter(t, StrategyPriority, specs("a"))
+
+	if _, err := r.pick("nonexistent"); err == nil {
+		t.Error("expected an error for an unknown override")
+	}
+}
+
+func TestPickOverrideUnhealthy(t *testing.T) {
+	r := newTestRouter(t, StrategyPriority, specs("a"))
+	r.Record("a", time.Millisecond, errSomething)
+
+	if _, err := r.pick("a"); err == nil {
+		t.Error("expected an error for an unhealthy override")
+	}
+}
+
+func TestPickNoHealthyBackends(t *testing.T) {
+	r := newTestRouter(t, StrategyPriority, specs("a"))
+	r.Record("a", time.Millisecond, errSomething)
+
+	if _, err := r.pick(""); err == nil {
+		t.Error("expected an error when no backend is healthy")
+	}
+}
+
+func TestPickRoundRobinCyclesThroughBackends(t *testing.T) {
+	r := newTestRouter(t, StrategyRoundRobin, specs("a", "b", "c"))
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		b, err := r.pick("")
+		if err != nil {
+			t.Fatalf("pick: %v", err)
+		}
+		got = append(got, b.spec.Name)
+	}
+	want := []string{"a", "b", "c", "a", "b", "c"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPickLeastLatencyPrefersFastest(t *testing.T) {
+	r := newTestRouter(t, StrategyLeastLatency, specs("slow", "fast"))
+	r.Record("slow", 500*time.Millisecond, nil)
+	r.Record("fast", 10*time.Millisecond, nil)
+
+	b, err := r.pick("")
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	if b.spec.Name != "fast" {
+		t.Errorf("picked %q, want %q", b.spec.Name, "fast")
+	}
+}
+
+func TestPickWeightedDistributesProportionally(t *testing.T) {
+	r := newTestRouter(t, StrategyWeighted, []BackendSpec{
+		{Name: "heavy", Config: providers.Config{Provider: "openai"}, Weight: 3},
+		{Name: "light", Config: providers.Config{Provider: "openai"}, Weight: 1},
+	})
+
+	counts := map[string]int{}
+	for i := 0; i < 8; i++ {
+		b, err := r.pick("")
+		if err != nil {
+			t.Fatalf("pick: %v", err)
+		}
+		counts[b.spec.Name]++
+	}
+	if counts["heavy"] != 6 || counts["light"] != 2 {
+		t.Errorf("got counts %v, want heavy=6 light=2 over 8 picks at weights 3:1", counts)
+	}
+}
+
+// errSomething is a health-affecting error per isHealthAffecting (it must
+// look like a backend-side failure, not a caller-side one), used to eject a
+// backend in tests.
+var errSomething = &testError{"request timeout"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,253 @@
+// Package router dispatches chat requests across multiple configured model
+// backends, tracking each backend's health and failing over away from ones
+// that are erroring, rate-limited, or slow.
+package router
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/luomsis/agent4everything/translator/eino/providers"
+)
+
+// Strategy selects which healthy backend handles the next request.
+type Strategy string
+
+const (
+	StrategyPriority      Strategy = "priority"
+	StrategyRoundRobin    Strategy = "round_robin"
+	StrategyLeastLatency  Strategy = "least_latency"
+	StrategyWeighted      Strategy = "weighted"
+)
+
+// BackendSpec describes one configured backend.
+type BackendSpec struct {
+	Name     string
+	Config   providers.Config
+	Priority int // lower runs first under StrategyPriority
+	Weight   int // relative share under StrategyWeighted; values <= 0 count as 1
+
+	// Build, if set, overrides the Router's default ChainBuilder for this
+	// backend only — used by the YAML model loader, where each model can
+	// define its own prompt template.
+	Build ChainBuilder
+
+	// Wrap, if set, post-processes the model.BaseChatModel built from
+	// Config before it's used — used by the YAML model loader to apply a
+	// model's configured sampling Parameters as defaults on every call.
+	Wrap func(model.BaseChatModel) model.BaseChatModel
+
+	// SystemPrompt, if set, is this backend's YAML-configured system prompt
+	// (already rendered to literal text — no template placeholders). Used
+	// by callers that build their own chain around a backend's raw model
+	// (e.g. a RAG retrieval chain) and still want that backend's persona
+	// instead of falling back to a hardcoded default.
+	SystemPrompt string
+}
+
+// ChainBuilder compiles a chat model into the Eino chain used to serve
+// requests. Passed in rather than hardcoded so the router stays decoupled
+// from createEinoChatChain's prompt/template wiring.
+type ChainBuilder func(ctx context.Context, chatModel model.BaseChatModel) (compose.Runnable[[]*schema.Message, *schema.Message], error)
+
+// State is the health/latency snapshot returned by GET /router/state.
+type State struct {
+	Name           string        `json:"name"`
+	Healthy        bool          `json:"healthy"`
+	ConsecutiveErr int           `json:"consecutive_errors"`
+	LastError      string        `json:"last_error,omitempty"`
+	LastLatencyMS  int64         `json:"last_latency_ms"`
+	EjectedUntil   *time.Time    `json:"ejected_until,omitempty"`
+}
+
+type backend struct {
+	spec  BackendSpec
+	model model.BaseChatModel
+	chain compose.Runnable[[]*schema.Message, *schema.Message]
+
+	mu             sync.Mutex
+	healthy        bool
+	consecutiveErr int
+	lastErr        error
+	lastLatency    time.Duration
+	ejectedUntil   time.Time
+}
+
+// Router dispatches across backend, rebuilding each backend's chain lazily
+// (once, on first use) via build.
+type Router struct {
+	strategy Strategy
+	backends []*backend
+	byName   map[string]*backend
+	build    ChainBuilder
+
+	mu     sync.Mutex
+	rrNext int
+}
+
+// New builds a Router over specs. Each backend's model.BaseChatModel is
+// constructed eagerly via providers.New; its Eino chain is compiled lazily
+// on first dispatch via build.
+func New(strategy Strategy, specs []BackendSpec, build ChainBuilder) (*Router, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("router: at least one backend is required")
+	}
+
+	r := &Router{strategy: strategy, build: build, byName: make(map[string]*backend, len(specs))}
+	for _, spec := range specs {
+		chatModel, err := providers.New(spec.Config)
+		if err != nil {
+			return nil, fmt.Errorf("router: failed to build backend %q: %v", spec.Name, err)
+		}
+		if spec.Wrap != nil {
+			chatModel = spec.Wrap(chatModel)
+		}
+		b := &backend{spec: spec, model: chatModel, healthy: true}
+		r.backends = append(r.backends, b)
+		r.byName[spec.Name] = b
+	}
+	return r, nil
+}
+
+// Chain returns the compiled Eino chain and name for the backend that
+// should serve the next request: the named override if given and healthy,
+// otherwise the strategy's pick among healthy backends.
+func (r *Router) Chain(ctx context.Context, override string) (compose.Runnable[[]*schema.Message, *schema.Message], string, error) {
+	b, err := r.pick(override)
+	if err != nil {
+		return nil, "", err
+	}
+	chain, err := r.compiledChain(ctx, b)
+	if err != nil {
+		return nil, "", err
+	}
+	return chain, b.spec.Name, nil
+}
+
+// Model returns the model.BaseChatModel for the backend that should serve
+// the next request, selected by the same rules as Chain, but without
+// compiling or caching a chain around it. Used by callers that need to wrap
+// a backend's raw model in a chain of their own, e.g. the RAG retrieval
+// chain, which varies per request rather than per backend.
+func (r *Router) Model(override string) (model.BaseChatModel, string, error) {
+	b, err := r.pick(override)
+	if err != nil {
+		return nil, "", err
+	}
+	return b.model, b.spec.Name, nil
+}
+
+// SystemPromptFor returns name's configured SystemPrompt, or "" if name is
+// unknown or that backend has no configured system prompt (e.g. it was
+// built from plain environment variables rather than a YAML model config).
+func (r *Router) SystemPromptFor(name string) string {
+	b, ok := r.byName[name]
+	if !ok {
+		return ""
+	}
+	return b.spec.SystemPrompt
+}
+
+// Record updates a backend's health after a dispatch attempt. Call it with
+// the latency and error (nil on success) observed invoking that backend's
+// chain.
+func (r *Router) Record(name string, latency time.Duration, err error) {
+	b, ok := r.byName[name]
+	if !ok {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastLatency = latency
+	if err == nil {
+		b.healthy = true
+		b.consecutiveErr = 0
+		b.lastErr = nil
+		b.ejectedUntil = time.Time{}
+		return
+	}
+	if !isHealthAffecting(err) {
+		// Transient/caller-side errors (bad input, context cancellation)
+		// shouldn't eject a backend that's otherwise fine.
+		return
+	}
+
+	b.lastErr = err
+	b.consecutiveErr++
+	b.healthy = false
+	b.ejectedUntil = time.Now().Add(backoff(b.consecutiveErr))
+}
+
+// State returns a point-in-time health/latency snapshot for every backend.
+func (r *Router) State() []State {
+	out := make([]State, 0, len(r.backends))
+	for _, b := range r.backends {
+		b.mu.Lock()
+		s := State{
+			Name:           b.spec.Name,
+			Healthy:        b.isHealthyLocked(),
+			ConsecutiveErr: b.consecutiveErr,
+			LastLatencyMS:  b.lastLatency.Milliseconds(),
+		}
+		if b.lastErr != nil {
+			s.LastError = b.lastErr.Error()
+		}
+		if !b.ejectedUntil.IsZero() {
+			t := b.ejectedUntil
+			s.EjectedUntil = &t
+		}
+		b.mu.Unlock()
+		out = append(out, s)
+	}
+	return out
+}
+
+func (b *backend) isHealthyLocked() bool {
+	if b.healthy {
+		return true
+	}
+	return time.Now().After(b.ejectedUntil)
+}
+
+func (r *Router) compiledChain(ctx context.Context, b *backend) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.chain != nil {
+		return b.chain, nil
+	}
+	build := r.build
+	if b.spec.Build != nil {
+		build = b.spec.Build
+	}
+	chain, err := build(ctx, b.model)
+	if err != nil {
+		return nil, fmt.Errorf("router: failed to build chain for backend %q: %v", b.spec.Name, err)
+	}
+	b.chain = chain
+	return chain, nil
+}
+
+// backoff implements exponential backoff for ejected backends, capped at 5
+// minutes so a transiently-unhealthy backend is eventually retried.
+func backoff(consecutiveErr int) time.Duration {
+	d := time.Duration(1<<uint(min(consecutiveErr, 9))) * time.Second
+	if d > 5*time.Minute {
+		d = 5 * time.Minute
+	}
+	return d
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/cloudwego/eino/components/model"
+)
+
+// NewStoreFromEnv builds the Store selected by SESSION_STORE: "memory"
+// (the default) or "sqlite". SESSION_DB_PATH selects the SQLite file when
+// SESSION_STORE=sqlite (default "sessions.db").
+func NewStoreFromEnv() (Store, error) {
+	switch backend := os.Getenv("SESSION_STORE"); backend {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "sqlite":
+		path := os.Getenv("SESSION_DB_PATH")
+		if path == "" {
+			path = "sessions.db"
+		}
+		return NewSQLiteStore(path)
+	default:
+		return nil, fmt.Errorf("session: unknown SESSION_STORE %q", backend)
+	}
+}
+
+// NewTrimStrategyFromEnv builds the TrimStrategy selected by
+// SESSION_TRIM_STRATEGY: "sliding_window" (the default), "drop_oldest", or
+// "summarize". summarizeModel is used by the summarize strategy to
+// generate its summaries; it may be nil as long as SESSION_TRIM_STRATEGY
+// is never "summarize".
+func NewTrimStrategyFromEnv(summarizeModel model.BaseChatModel) (TrimStrategy, error) {
+	switch strategy := os.Getenv("SESSION_TRIM_STRATEGY"); strategy {
+	case "", "sliding_window":
+		return SlidingWindowTrim{}, nil
+	case "drop_oldest":
+		keep, err := trimKeepFromEnv(20)
+		if err != nil {
+			return nil, err
+		}
+		return DropOldestTrim{KeepMessages: keep}, nil
+	case "summarize":
+		if summarizeModel == nil {
+			return nil, fmt.Errorf("session: SESSION_TRIM_STRATEGY=summarize requires a configured chat model")
+		}
+		keep, err := trimKeepFromEnv(6)
+		if err != nil {
+			return nil, err
+		}
+		return SummarizeTrim{Model: summarizeModel, Keep: keep}, nil
+	default:
+		return nil, fmt.Errorf("session: unknown SESSION_TRIM_STRATEGY %q", strategy)
+	}
+}
+
+func trimKeepFromEnv(fallback int) (int, error) {
+	v := os.Getenv("SESSION_TRIM_KEEP_MESSAGES")
+	if v == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("session: invalid SESSION_TRIM_KEEP_MESSAGES %q: %v", v, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store: simplest to run, but history is lost
+// on restart and isn't shared across instances. Suitable as the default for
+// local development and single-instance deployments.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]*Session)}
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *sess
+	cp.Messages = append([]Message(nil), sess.Messages...)
+	return &cp, nil
+}
+
+func (s *MemoryStore) Replace(_ context.Context, id string, messages []Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[id] = &Session{
+		ID:        id,
+		Messages:  append([]Message(nil), messages...),
+		UpdatedAt: time.Now(),
+	}
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
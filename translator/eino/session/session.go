@@ -0,0 +1,77 @@
+// Package session persists chat history across requests, keyed by a
+// client-supplied or server-generated session_id, and prunes that history
+// to fit a model's context window via a pluggable TrimStrategy.
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// ErrNotFound is returned by Store.Get when no session exists for the
+// given id.
+var ErrNotFound = errors.New("session: not found")
+
+// Message is one stored conversation turn: the Eino message plus the
+// bookkeeping chatHandler and the trim strategies need.
+type Message struct {
+	*schema.Message
+	Timestamp  time.Time `json:"timestamp"`
+	TokenCount int       `json:"token_count"`
+}
+
+// NewMessage wraps msg as a Message stamped with the current time and an
+// estimated token count.
+func NewMessage(msg *schema.Message) Message {
+	return Message{Message: msg, Timestamp: time.Now(), TokenCount: EstimateTokens(msg.Content)}
+}
+
+// EstimateTokens approximates a message's token count from its character
+// length (~4 characters per token for English text), since the exact count
+// depends on a tokenizer specific to the configured model/provider.
+func EstimateTokens(content string) int {
+	if content == "" {
+		return 0
+	}
+	if n := len(content) / 4; n > 0 {
+		return n
+	}
+	return 1
+}
+
+// Unwrap discards the session bookkeeping and returns the underlying Eino
+// messages, in order, for passing to a chat chain.
+func Unwrap(messages []Message) []*schema.Message {
+	out := make([]*schema.Message, len(messages))
+	for i, m := range messages {
+		out[i] = m.Message
+	}
+	return out
+}
+
+// NewID generates a session_id for callers that don't supply their own.
+func NewID() string {
+	return fmt.Sprintf("sess-%d", time.Now().UnixNano())
+}
+
+// Session is one conversation's full, persisted history.
+type Session struct {
+	ID        string    `json:"id"`
+	Messages  []Message `json:"messages"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Store persists sessions keyed by session_id.
+type Store interface {
+	// Get returns the session for id, or ErrNotFound if none exists.
+	Get(ctx context.Context, id string) (*Session, error)
+	// Replace overwrites (or creates) a session's full message history.
+	Replace(ctx context.Context, id string, messages []Message) error
+	// Delete removes a session. It is not an error to delete a session
+	// that doesn't exist.
+	Delete(ctx context.Context, id string) error
+}
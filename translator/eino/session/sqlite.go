@@ -0,0 +1,82 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore persists sessions to a SQLite database so conversation
+// history survives process restarts. Each session is one row holding its
+// messages as a JSON blob, which is simpler than a normalized schema and
+// avoids committing to schema.Message's field layout.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if necessary) the SQLite database at path
+// and ensures the sessions table exists.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to open %s: %v", path, err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS sessions (
+		id TEXT PRIMARY KEY,
+		messages TEXT NOT NULL,
+		updated_at INTEGER NOT NULL
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("session: failed to create sessions table: %v", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, id string) (*Session, error) {
+	var raw string
+	var updatedAt int64
+	err := s.db.QueryRowContext(ctx, `SELECT messages, updated_at FROM sessions WHERE id = ?`, id).Scan(&raw, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to load %q: %v", id, err)
+	}
+
+	var messages []Message
+	if err := json.Unmarshal([]byte(raw), &messages); err != nil {
+		return nil, fmt.Errorf("session: failed to decode %q: %v", id, err)
+	}
+	return &Session{ID: id, Messages: messages, UpdatedAt: time.Unix(updatedAt, 0)}, nil
+}
+
+func (s *SQLiteStore) Replace(ctx context.Context, id string, messages []Message) error {
+	raw, err := json.Marshal(messages)
+	if err != nil {
+		return fmt.Errorf("session: failed to encode %q: %v", id, err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO sessions (id, messages, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET messages = excluded.messages, updated_at = excluded.updated_at`,
+		id, string(raw), time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("session: failed to save %q: %v", id, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, id string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("session: failed to delete %q: %v", id, err)
+	}
+	return nil
+}
+
+// Close releases the underlying database handle.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// TrimStrategy prunes a session's message history to fit within
+// contextSize tokens. chatHandler applies it to every turn, both to decide
+// what's sent to the model and what's persisted back to the Store.
+type TrimStrategy interface {
+	Trim(ctx context.Context, messages []Message, contextSize int) ([]Message, error)
+}
+
+func tokenTotal(messages []Message) int {
+	total := 0
+	for _, m := range messages {
+		total += m.TokenCount
+	}
+	return total
+}
+
+// SlidingWindowTrim drops the oldest messages, one at a time, until the
+// remaining history's estimated token count fits within contextSize. It
+// always keeps at least the most recent message, even if that message
+// alone exceeds contextSize.
+type SlidingWindowTrim struct{}
+
+func (SlidingWindowTrim) Trim(_ context.Context, messages []Message, contextSize int) ([]Message, error) {
+	for len(messages) > 1 && tokenTotal(messages) > contextSize {
+		messages = messages[1:]
+	}
+	return messages, nil
+}
+
+// DropOldestTrim keeps only the most recent KeepMessages messages,
+// regardless of their token count. Cheaper than SlidingWindowTrim, at the
+// cost of not reacting to unusually long individual turns.
+type DropOldestTrim struct {
+	KeepMessages int
+}
+
+func (t DropOldestTrim) Trim(_ context.Context, messages []Message, _ int) ([]Message, error) {
+	if t.KeepMessages <= 0 || len(messages) <= t.KeepMessages {
+		return messages, nil
+	}
+	return messages[len(messages)-t.KeepMessages:], nil
+}
+
+// SummarizeTrim keeps the most recent Keep messages verbatim and, once the
+// full history exceeds contextSize tokens, replaces everything older with
+// a single system message summarizing them, produced by a secondary call
+// to Model.
+type SummarizeTrim struct {
+	Model model.BaseChatModel
+	Keep  int
+}
+
+func (t SummarizeTrim) Trim(ctx context.Context, messages []Message, contextSize int) ([]Message, error) {
+	if len(messages) <= t.Keep || tokenTotal(messages) <= contextSize {
+		return messages, nil
+	}
+
+	older, recent := messages[:len(messages)-t.Keep], messages[len(messages)-t.Keep:]
+	summary, err := t.summarize(ctx, older)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Message, 0, 1+len(recent))
+	out = append(out, NewMessage(&schema.Message{Role: schema.System, Content: "Summary of earlier conversation: " + summary}))
+	out = append(out, recent...)
+	return out, nil
+}
+
+func (t SummarizeTrim) summarize(ctx context.Context, older []Message) (string, error) {
+	request := []*schema.Message{
+		{Role: schema.System, Content: "Summarize the following conversation concisely, preserving the facts and decisions a reader would need to continue it."},
+	}
+	request = append(request, Unwrap(older)...)
+
+	resp, err := t.Model.Generate(ctx, request)
+	if err != nil {
+		return "", fmt.Errorf("session: failed to summarize older turns: %v", err)
+	}
+	return resp.Content, nil
+}
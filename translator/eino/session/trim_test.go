@@ -0,0 +1,144 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+func msgWithTokens(content string, tokens int) Message {
+	m := NewMessage(&schema.Message{Role: schema.User, Content: content})
+	m.TokenCount = tokens
+	return m
+}
+
+func TestSlidingWindowTrim(t *testing.T) {
+	messages := []Message{
+		msgWithTokens("a", 10),
+		msgWithTokens("b", 10),
+		msgWithTokens("c", 10),
+	}
+
+	out, err := SlidingWindowTrim{}.Trim(context.Background(), messages, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 || out[0].Content != "c" {
+		t.Errorf("got %v, want only the last message", contents(out))
+	}
+}
+
+func TestSlidingWindowTrimKeepsAtLeastOneMessage(t *testing.T) {
+	messages := []Message{msgWithTokens("only", 1000)}
+
+	out, err := SlidingWindowTrim{}.Trim(context.Background(), messages, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 1 {
+		t.Errorf("got %d messages, want 1 even though it exceeds contextSize", len(out))
+	}
+}
+
+func TestSlidingWindowTrimNoopWhenWithinBudget(t *testing.T) {
+	messages := []Message{msgWithTokens("a", 5), msgWithTokens("b", 5)}
+
+	out, err := SlidingWindowTrim{}.Trim(context.Background(), messages, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Errorf("got %d messages, want 2 (nothing to trim)", len(out))
+	}
+}
+
+func TestDropOldestTrim(t *testing.T) {
+	messages := []Message{
+		msgWithTokens("a", 1),
+		msgWithTokens("b", 1),
+		msgWithTokens("c", 1),
+	}
+
+	out, err := DropOldestTrim{KeepMessages: 2}.Trim(context.Background(), messages, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := contents(out); got != "bc" {
+		t.Errorf("got %q, want %q", got, "bc")
+	}
+}
+
+func TestDropOldestTrimKeepMessagesUnsetIsNoop(t *testing.T) {
+	messages := []Message{msgWithTokens("a", 1), msgWithTokens("b", 1)}
+
+	out, err := DropOldestTrim{}.Trim(context.Background(), messages, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Errorf("got %d messages, want 2 (KeepMessages<=0 is a no-op)", len(out))
+	}
+}
+
+// stubSummarizeModel implements model.BaseChatModel, returning a fixed
+// summary regardless of input.
+type stubSummarizeModel struct {
+	summary string
+}
+
+func (s *stubSummarizeModel) Generate(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.Message, error) {
+	return &schema.Message{Role: schema.Assistant, Content: s.summary}, nil
+}
+
+func (s *stubSummarizeModel) Stream(_ context.Context, _ []*schema.Message, _ ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sr, sw := schema.Pipe[*schema.Message](1)
+	sw.Send(&schema.Message{Role: schema.Assistant, Content: s.summary}, nil)
+	sw.Close()
+	return sr, nil
+}
+
+func TestSummarizeTrimNoopWhenWithinBudget(t *testing.T) {
+	messages := []Message{msgWithTokens("a", 1), msgWithTokens("b", 1)}
+	strategy := SummarizeTrim{Model: &stubSummarizeModel{summary: "should not be used"}, Keep: 5}
+
+	out, err := strategy.Trim(context.Background(), messages, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Errorf("got %d messages, want 2 (nothing to summarize)", len(out))
+	}
+}
+
+func TestSummarizeTrimSummarizesOlderMessages(t *testing.T) {
+	messages := []Message{
+		msgWithTokens("old1", 50),
+		msgWithTokens("old2", 50),
+		msgWithTokens("recent", 50),
+	}
+	strategy := SummarizeTrim{Model: &stubSummarizeModel{summary: "the gist"}, Keep: 1}
+
+	out, err := strategy.Trim(context.Background(), messages, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out) != 2 {
+		t.Fatalf("got %d messages, want 2 (summary + kept recent)", len(out))
+	}
+	if out[0].Role != schema.System || out[0].Content != "Summary of earlier conversation: the gist" {
+		t.Errorf("out[0] = %+v, want a system summary message", out[0])
+	}
+	if out[1].Content != "recent" {
+		t.Errorf("out[1].Content = %q, want %q", out[1].Content, "recent")
+	}
+}
+
+func contents(messages []Message) string {
+	out := ""
+	for _, m := range messages {
+		out += m.Content
+	}
+	return out
+}